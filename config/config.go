@@ -1,12 +1,23 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	PORT         string `mapstructure:"PORT"`
 	CQL_KEYSPACE string `mapstructure:"CQL_KEYSPACE"`
 	CQL_HOSTS    string `mapstructure:"CQL_HOSTS"`
 	NATS_CLUSTER string `mapstructure:"NATS_CLUSTER"`
+
+	PARTY_SERVICE_ADDRESS string `mapstructure:"PARTY_SERVICE_ADDRESS"`
+
+	FRIEND_REQUEST_TTL time.Duration `mapstructure:"FRIEND_REQUEST_TTL"`
+	SWEEP_INTERVAL     time.Duration `mapstructure:"SWEEP_INTERVAL"`
+	TOKEN_SECRET       string        `mapstructure:"TOKEN_SECRET"`
 }
 
 func LoadConfig() (config Config, err error) {
@@ -15,6 +26,8 @@ func LoadConfig() (config Config, err error) {
 	viper.SetConfigType("env")
 
 	viper.AutomaticEnv()
+	// Secrets aren't part of the env files, binding makes Unmarshal pick them up from the environment
+	viper.BindEnv("TOKEN_SECRET")
 
 	err = viper.ReadInConfig()
 	if err != nil {
@@ -22,5 +35,17 @@ func LoadConfig() (config Config, err error) {
 	}
 
 	err = viper.Unmarshal(&config)
+	if err != nil {
+		return
+	}
+
+	// A zero interval panics the sweeper and a zero ttl expires every request right away
+	if config.FRIEND_REQUEST_TTL <= 0 {
+		return config, errors.New("FRIEND_REQUEST_TTL has to be a positive duration")
+	}
+	if config.SWEEP_INTERVAL <= 0 {
+		return config, errors.New("SWEEP_INTERVAL has to be a positive duration")
+	}
+
 	return
 }
@@ -4,20 +4,50 @@ import (
 	"context"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/clubo-app/packages/stream"
 	"github.com/clubo-app/protobuf/events"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/clubo-app/relation-service/repository"
 	"github.com/clubo-app/relation-service/service"
 )
 
+// Number of friends whose feeds are written in one batch
+const fanOutPageSize = 50
+
+// Rows removed per batch when cleaning up a deleted party and the pause between batches
+const (
+	cleanupPageSize = 100
+	cleanupPause    = 200 * time.Millisecond
+)
+
 type consumer struct {
 	stream stream.Stream
 	fs     service.FriendRelationService
 	ps     service.FavoriteParty
+	fc     service.FavoriteCollectionService
+	pp     service.PartyParticipantsService
+	st     service.PartySettingsService
+	pr     service.PartyRsvpService
+	ro     service.PartyRoleService
+	fa     service.FriendActivityService
+	il     service.PartyInviteLinkService
 }
 
-func New(stream stream.Stream, fs service.FriendRelationService, ps service.FavoriteParty) consumer {
-	return consumer{stream: stream, fs: fs, ps: ps}
+func New(
+	stream stream.Stream,
+	fs service.FriendRelationService,
+	ps service.FavoriteParty,
+	fc service.FavoriteCollectionService,
+	pp service.PartyParticipantsService,
+	st service.PartySettingsService,
+	pr service.PartyRsvpService,
+	ro service.PartyRoleService,
+	fa service.FriendActivityService,
+	il service.PartyInviteLinkService,
+) consumer {
+	return consumer{stream: stream, fs: fs, ps: ps, fc: fc, pp: pp, st: st, pr: pr, ro: ro, fa: fa, il: il}
 }
 
 func (c consumer) Start() {
@@ -28,6 +58,18 @@ func (c consumer) Start() {
 	go c.stream.SubscribeToEvent("relation.friend.removed.count", events.FriendRemoved{}, c.FriendRemoved)
 	go c.stream.SubscribeToEvent("relation.party.favorited.count", events.PartyFavorited{}, c.PartyFavorited)
 	go c.stream.SubscribeToEvent("relation.party.unfavorited.count", events.PartyUnfavorited{}, c.PartyUnfavorited)
+	go c.stream.SubscribeToEvent("relation.party.joined.count", events.PartyJoined{}, c.PartyJoined)
+	go c.stream.SubscribeToEvent("relation.party.left.count", events.PartyLeft{}, c.PartyLeft)
+	go c.stream.SubscribeToEvent("relation.party.rsvp.changed.count", events.PartyRsvpChanged{}, c.PartyRsvpChanged)
+	go c.stream.SubscribeToEvent("relation.party.plus_ones.changed.count", events.PartyPlusOnesChanged{}, c.PartyPlusOnesChanged)
+	go c.stream.SubscribeToEvent("relation.party.checked_in.count", events.PartyCheckedIn{}, c.PartyCheckedIn)
+	go c.stream.SubscribeToEvent("relation.party.check_in.removed.count", events.PartyCheckInRemoved{}, c.PartyCheckInRemoved)
+	go c.stream.SubscribeToEvent("relation.party.favorited.feed", events.PartyFavorited{}, c.PartyFavoritedFeed)
+	go c.stream.SubscribeToEvent("relation.party.joined.feed", events.PartyJoined{}, c.PartyJoinedFeed)
+	go c.stream.SubscribeToEvent("relation.party.created.host", events.PartyCreated{}, c.PartyCreated)
+	go c.stream.SubscribeToEvent("relation.party.deleted.cleanup", events.PartyDeleted{}, c.PartyDeleted)
+	go c.stream.SubscribeToEvent("relation.profile.created.name", events.ProfileCreated{}, c.ProfileCreated)
+	go c.stream.SubscribeToEvent("relation.profile.updated.name", events.ProfileUpdated{}, c.ProfileUpdated)
 
 	wg.Wait()
 }
@@ -54,6 +96,12 @@ func (c consumer) PartyFavorited(e *events.PartyFavorited) {
 	if err != nil {
 		log.Println("Error decreasing Count: ", err)
 	}
+
+	err = c.ps.IncreaseHourlyFavoriteCount(context.Background(), e.PartyId)
+
+	if err != nil {
+		log.Println("Error increasing Hourly Count: ", err)
+	}
 }
 
 func (c consumer) PartyUnfavorited(e *events.PartyUnfavorited) {
@@ -63,3 +111,314 @@ func (c consumer) PartyUnfavorited(e *events.PartyUnfavorited) {
 		log.Println("Error decreasing Count: ", err)
 	}
 }
+
+func (c consumer) PartyJoined(e *events.PartyJoined) {
+	err := c.pp.IncreaseParticipantCount(context.Background(), e.PartyId)
+
+	if err != nil {
+		log.Println("Error increasing Count: ", err)
+	}
+}
+
+func (c consumer) PartyLeft(e *events.PartyLeft) {
+	err := c.pp.DecreaseParticipantCount(context.Background(), e.PartyId)
+
+	if err != nil {
+		log.Println("Error decreasing Count: ", err)
+	}
+}
+
+func (c consumer) PartyRsvpChanged(e *events.PartyRsvpChanged) {
+	if e.PreviousStatus != "" {
+		err := c.pr.DecreaseRsvpCount(context.Background(), e.PartyId, e.PreviousStatus)
+
+		if err != nil {
+			log.Println("Error decreasing Count: ", err)
+		}
+	}
+
+	err := c.pr.IncreaseRsvpCount(context.Background(), e.PartyId, e.Status)
+
+	if err != nil {
+		log.Println("Error increasing Count: ", err)
+	}
+}
+
+func (c consumer) PartyPlusOnesChanged(e *events.PartyPlusOnesChanged) {
+	err := c.pp.ChangeGuestCount(context.Background(), e.PartyId, int(e.Delta))
+
+	if err != nil {
+		log.Println("Error changing Count: ", err)
+	}
+}
+
+func (c consumer) PartyCheckedIn(e *events.PartyCheckedIn) {
+	err := c.pp.IncreaseCheckedInCount(context.Background(), e.PartyId)
+
+	if err != nil {
+		log.Println("Error increasing Count: ", err)
+	}
+}
+
+func (c consumer) PartyCheckInRemoved(e *events.PartyCheckInRemoved) {
+	err := c.pp.DecreaseCheckedInCount(context.Background(), e.PartyId)
+
+	if err != nil {
+		log.Println("Error decreasing Count: ", err)
+	}
+}
+
+func (c consumer) PartyFavoritedFeed(e *events.PartyFavorited) {
+	c.fanOut(e.UserId, e.PartyId, datastruct.ACTIVITY_FAVORITED)
+}
+
+func (c consumer) PartyJoinedFeed(e *events.PartyJoined) {
+	c.fanOut(e.UserId, e.PartyId, datastruct.ACTIVITY_JOINED)
+}
+
+// Writes the activity into the feed of every accepted friend of the actor, one page of friends at a time
+func (c consumer) fanOut(actorId, pId, activityType string) {
+	ctx := context.Background()
+
+	var p []byte
+	for {
+		fs, next, err := c.fs.GetFriends(ctx, actorId, p, fanOutPageSize)
+		if err != nil {
+			log.Println("Error getting Friends: ", err)
+			return
+		}
+
+		var fIds []string
+		for _, f := range fs {
+			fIds = append(fIds, f.FriendId)
+		}
+
+		err = c.fa.FanOut(ctx, fIds, actorId, pId, activityType)
+		if err != nil {
+			log.Println("Error fanning out Activity: ", err)
+		}
+
+		if len(next) == 0 {
+			return
+		}
+		p = next
+	}
+}
+
+func (c consumer) PartyCreated(e *events.PartyCreated) {
+	_, err := c.ro.AssignRole(context.Background(), e.PartyId, e.UserId, datastruct.ROLE_HOST)
+
+	if err != nil {
+		log.Println("Error assigning Host: ", err)
+	}
+}
+
+// Removes all relation data of the party. Every step only deletes what is still there,
+// so a redelivered event just finishes whatever a previous run left behind.
+// The counters go last, count events still in flight may recreate a counter row but it is never read again.
+func (c consumer) PartyDeleted(e *events.PartyDeleted) {
+	ctx := context.Background()
+
+	// Links go first so nobody can join the party through one while it is cleaned up
+	err := forEachPage(func(p []byte) ([]byte, error) {
+		ls, next, err := c.il.GetPartyInviteLinks(ctx, e.PartyId, p, cleanupPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var lIds []string
+		for _, l := range ls {
+			lIds = append(lIds, l.LinkId)
+		}
+
+		return next, c.il.DeleteInviteLinks(ctx, lIds)
+	})
+	if err != nil {
+		log.Println("Error deleting Invite Links: ", err)
+	}
+
+	err = forEachPage(func(p []byte) ([]byte, error) {
+		fs, next, err := c.ps.GetFavorisingUsersByParty(ctx, e.PartyId, p, cleanupPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var uIds []string
+		for _, f := range fs {
+			uIds = append(uIds, f.UserId)
+		}
+
+		return next, c.ps.RemoveFavorites(ctx, e.PartyId, uIds)
+	})
+	if err != nil {
+		log.Println("Error removing Favorites: ", err)
+	}
+
+	err = forEachPage(func(p []byte) ([]byte, error) {
+		cps, next, err := c.fc.GetPartyCollections(ctx, e.PartyId, p, cleanupPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var cIds []string
+		for _, cp := range cps {
+			cIds = append(cIds, cp.CollectionId)
+		}
+
+		return next, c.fc.RemoveCollectionParties(ctx, e.PartyId, cIds)
+	})
+	if err != nil {
+		log.Println("Error removing Collection Parties: ", err)
+	}
+
+	err = forEachPage(func(p []byte) ([]byte, error) {
+		ps, next, err := c.pp.GetPartyParticipants(ctx, repository.GetPartyParticipantsParams{
+			PId:   e.PartyId,
+			Page:  p,
+			Limit: cleanupPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var uIds []string
+		for _, pa := range ps {
+			uIds = append(uIds, pa.UserId)
+		}
+
+		return next, c.pp.RemoveParticipants(ctx, e.PartyId, uIds)
+	})
+	if err != nil {
+		log.Println("Error removing Participants: ", err)
+	}
+
+	err = forEachPage(func(p []byte) ([]byte, error) {
+		is, next, err := c.pp.GetPartyInvites(ctx, repository.GetPartyInvitesParams{
+			PId:   e.PartyId,
+			Page:  p,
+			Limit: cleanupPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return next, c.pp.RemoveInvites(ctx, is)
+	})
+	if err != nil {
+		log.Println("Error removing Invites: ", err)
+	}
+
+	err = forEachPage(func(p []byte) ([]byte, error) {
+		as, next, err := c.fa.GetPartyActivities(ctx, e.PartyId, p, cleanupPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		return next, c.fa.RemoveActivities(ctx, as)
+	})
+	if err != nil {
+		log.Println("Error removing Activities: ", err)
+	}
+
+	err = forEachPage(func(p []byte) ([]byte, error) {
+		ws, next, err := c.pp.GetWaitlist(ctx, repository.GetWaitlistParams{
+			PId:   e.PartyId,
+			Page:  p,
+			Limit: cleanupPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var uIds []string
+		for _, w := range ws {
+			uIds = append(uIds, w.UserId)
+		}
+
+		return next, c.pp.RemoveWaitlistEntries(ctx, e.PartyId, uIds)
+	})
+	if err != nil {
+		log.Println("Error removing Waitlist Entries: ", err)
+	}
+
+	err = forEachPage(func(p []byte) ([]byte, error) {
+		rs, next, err := c.pr.GetPartyRsvps(ctx, e.PartyId, p, cleanupPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var uIds []string
+		for _, r := range rs {
+			uIds = append(uIds, r.UserId)
+		}
+
+		return next, c.pr.RemoveRsvps(ctx, e.PartyId, uIds)
+	})
+	if err != nil {
+		log.Println("Error removing Rsvps: ", err)
+	}
+
+	err = c.pp.DeletePartyData(ctx, e.PartyId)
+	if err != nil {
+		log.Println("Error deleting Party Data: ", err)
+	}
+
+	err = c.st.DeletePartySettings(ctx, e.PartyId)
+	if err != nil {
+		log.Println("Error deleting Settings: ", err)
+	}
+
+	err = c.ro.DeletePartyRoles(ctx, e.PartyId)
+	if err != nil {
+		log.Println("Error deleting Roles: ", err)
+	}
+
+	err = c.pp.DeleteParticipantCount(ctx, e.PartyId)
+	if err != nil {
+		log.Println("Error deleting Participant Count: ", err)
+	}
+
+	err = c.pr.DeleteRsvpCounts(ctx, e.PartyId)
+	if err != nil {
+		log.Println("Error deleting Rsvp Counts: ", err)
+	}
+
+	err = c.ps.DeleteFavoriteCounts(ctx, e.PartyId)
+	if err != nil {
+		log.Println("Error deleting Favorite Counts: ", err)
+	}
+}
+
+// Calls fn with every page state until there is no next page, pausing between pages so large parties don't flood the cluster
+func forEachPage(fn func(p []byte) ([]byte, error)) error {
+	var p []byte
+	for {
+		next, err := fn(p)
+		if err != nil {
+			return err
+		}
+
+		if len(next) == 0 {
+			return nil
+		}
+		p = next
+
+		time.Sleep(cleanupPause)
+	}
+}
+
+func (c consumer) ProfileCreated(e *events.ProfileCreated) {
+	err := c.fs.UpdateDisplayName(context.Background(), e.UserId, e.DisplayName)
+
+	if err != nil {
+		log.Println("Error updating Display Name: ", err)
+	}
+}
+
+func (c consumer) ProfileUpdated(e *events.ProfileUpdated) {
+	err := c.fs.UpdateDisplayName(context.Background(), e.UserId, e.DisplayName)
+
+	if err != nil {
+		log.Println("Error updating Display Name: ", err)
+	}
+}
@@ -0,0 +1,22 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Block struct {
+	UserId    string    `db:"user_id"    validate:"required"`
+	BlockedId string    `db:"blocked_id" validate:"required"`
+	BlockedAt time.Time `db:"blocked_at" validate:"required"`
+}
+
+func (b Block) ToGRPCBlock() *rg.Block {
+	return &rg.Block{
+		UserId:    b.UserId,
+		BlockedId: b.BlockedId,
+		BlockedAt: timestamppb.New(b.BlockedAt),
+	}
+}
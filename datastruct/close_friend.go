@@ -0,0 +1,22 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CloseFriend struct {
+	UserId   string    `db:"user_id"   validate:"required"`
+	FriendId string    `db:"friend_id" validate:"required"`
+	AddedAt  time.Time `db:"added_at"  validate:"required"`
+}
+
+func (cf CloseFriend) ToGRPCCloseFriend() *rg.CloseFriend {
+	return &rg.CloseFriend{
+		UserId:   cf.UserId,
+		FriendId: cf.FriendId,
+		AddedAt:  timestamppb.New(cf.AddedAt),
+	}
+}
@@ -0,0 +1,40 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type FavoriteCollection struct {
+	UserId       string    `db:"user_id"       validate:"required"`
+	CollectionId string    `db:"collection_id" validate:"required"`
+	Name         string    `db:"name"          validate:"required,max=50"`
+	CreatedAt    time.Time `db:"created_at"    validate:"required"`
+}
+
+func (c FavoriteCollection) ToGRPCFavoriteCollection() *rg.FavoriteCollection {
+	return &rg.FavoriteCollection{
+		UserId:       c.UserId,
+		CollectionId: c.CollectionId,
+		Name:         c.Name,
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+	}
+}
+
+type FavoriteCollectionParty struct {
+	CollectionId string    `db:"collection_id" validate:"required"`
+	PartyId      string    `db:"party_id"      validate:"required"`
+	UserId       string    `db:"user_id"       validate:"required"`
+	AddedAt      time.Time `db:"added_at"      validate:"required"`
+}
+
+func (p FavoriteCollectionParty) ToGRPCFavoriteCollectionParty() *rg.FavoriteCollectionParty {
+	return &rg.FavoriteCollectionParty{
+		CollectionId: p.CollectionId,
+		PartyId:      p.PartyId,
+		UserId:       p.UserId,
+		AddedAt:      timestamppb.New(p.AddedAt),
+	}
+}
@@ -1,6 +1,15 @@
 package datastruct
 
+import "time"
+
 type FavoritePartyCount struct {
 	PartyId            string `db:"party_id"`
 	FavoritePartyCount int64  `db:"favorite_party_count"`
 }
+
+// Favorites of a party within one hour, negative if it was unfavorited more often
+type HourlyFavoriteCount struct {
+	Hour          time.Time `db:"hour"`
+	PartyId       string    `db:"party_id"`
+	FavoriteCount int64     `db:"favorite_count"`
+}
@@ -0,0 +1,44 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	ACTIVITY_FAVORITED string = "favorited"
+	ACTIVITY_JOINED    string = "joined"
+)
+
+// An entry in the feed of UserId about something his friend ActorId did
+type FriendActivity struct {
+	UserId     string    `db:"user_id"     validate:"required"`
+	ActivityId string    `db:"activity_id" validate:"required"`
+	ActorId    string    `db:"actor_id"    validate:"required"`
+	PartyId    string    `db:"party_id"    validate:"required"`
+	Type       string    `db:"type"        validate:"required,oneof=favorited joined"`
+	CreatedAt  time.Time `db:"created_at"  validate:"required"`
+}
+
+func (a FriendActivity) ToGRPCFriendActivity() *rg.FriendActivity {
+	return &rg.FriendActivity{
+		ActivityId: a.ActivityId,
+		ActorId:    a.ActorId,
+		PartyId:    a.PartyId,
+		Type:       ActivityTypeToGRPC(a.Type),
+		CreatedAt:  timestamppb.New(a.CreatedAt),
+	}
+}
+
+func ActivityTypeToGRPC(t string) rg.ActivityType {
+	switch t {
+	case ACTIVITY_FAVORITED:
+		return rg.ActivityType_FAVORITED
+	case ACTIVITY_JOINED:
+		return rg.ActivityType_JOINED
+	default:
+		return rg.ActivityType_UNKNOWN
+	}
+}
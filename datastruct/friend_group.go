@@ -0,0 +1,38 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type FriendGroup struct {
+	UserId    string    `db:"user_id"    validate:"required"`
+	GroupId   string    `db:"group_id"   validate:"required"`
+	Name      string    `db:"name"       validate:"required,max=50"`
+	CreatedAt time.Time `db:"created_at" validate:"required"`
+}
+
+func (g FriendGroup) ToGRPCFriendGroup() *rg.FriendGroup {
+	return &rg.FriendGroup{
+		UserId:    g.UserId,
+		GroupId:   g.GroupId,
+		Name:      g.Name,
+		CreatedAt: timestamppb.New(g.CreatedAt),
+	}
+}
+
+type FriendGroupMember struct {
+	GroupId string    `db:"group_id" validate:"required"`
+	UserId  string    `db:"user_id"  validate:"required"`
+	AddedAt time.Time `db:"added_at" validate:"required"`
+}
+
+func (m FriendGroupMember) ToGRPCFriendGroupMember() *rg.FriendGroupMember {
+	return &rg.FriendGroupMember{
+		GroupId: m.GroupId,
+		UserId:  m.UserId,
+		AddedAt: timestamppb.New(m.AddedAt),
+	}
+}
@@ -8,19 +8,25 @@ import (
 )
 
 type FriendRelation struct {
-	UserId      string    `db:"user_id"      validate:"required"`
-	FriendId    string    `db:"friend_id"    validate:"required"`
-	Accepted    bool      `db:"accepted"`
-	RequestedAt time.Time `db:"requested_at" validate:"required"`
-	AcceptedAt  time.Time `db:"accepted_at"`
+	UserId        string    `db:"user_id"         validate:"required"`
+	FriendId      string    `db:"friend_id"       validate:"required"`
+	Accepted      bool      `db:"accepted"`
+	RequestedAt   time.Time `db:"requested_at"    validate:"required"`
+	AcceptedAt    time.Time `db:"accepted_at"`
+	ExpiresAt     time.Time `db:"expires_at"`
+	Message       string    `db:"message"         validate:"max=200"`
+	SourcePartyId string    `db:"source_party_id"`
 }
 
 func (fr FriendRelation) ToGRPCFriendRelation() *rg.FriendRelation {
 	return &rg.FriendRelation{
-		UserId:      fr.UserId,
-		FriendId:    fr.FriendId,
-		Accepted:    fr.Accepted,
-		RequestedAt: timestamppb.New(fr.RequestedAt),
-		AcceptedAt:  timestamppb.New(fr.AcceptedAt),
+		UserId:        fr.UserId,
+		FriendId:      fr.FriendId,
+		Accepted:      fr.Accepted,
+		RequestedAt:   timestamppb.New(fr.RequestedAt),
+		AcceptedAt:    timestamppb.New(fr.AcceptedAt),
+		ExpiresAt:     timestamppb.New(fr.ExpiresAt),
+		Message:       fr.Message,
+		SourcePartyId: fr.SourcePartyId,
 	}
 }
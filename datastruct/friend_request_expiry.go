@@ -0,0 +1,10 @@
+package datastruct
+
+import "time"
+
+type FriendRequestExpiry struct {
+	ExpiresOn time.Time `db:"expires_on" validate:"required"`
+	ExpiresAt time.Time `db:"expires_at" validate:"required"`
+	UserId    string    `db:"user_id"    validate:"required"`
+	FriendId  string    `db:"friend_id"  validate:"required"`
+}
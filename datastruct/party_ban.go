@@ -0,0 +1,24 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PartyBan struct {
+	PartyId  string    `db:"party_id"  validate:"required"`
+	UserId   string    `db:"user_id"   validate:"required"`
+	BannedBy string    `db:"banned_by" validate:"required"`
+	BannedAt time.Time `db:"banned_at" validate:"required"`
+}
+
+func (b PartyBan) ToGRPCPartyBan() *rg.PartyBan {
+	return &rg.PartyBan{
+		PartyId:  b.PartyId,
+		UserId:   b.UserId,
+		BannedBy: b.BannedBy,
+		BannedAt: timestamppb.New(b.BannedAt),
+	}
+}
@@ -0,0 +1,24 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PartyCheckIn struct {
+	PartyId     string    `db:"party_id"      validate:"required"`
+	UserId      string    `db:"user_id"       validate:"required"`
+	CheckedInAt time.Time `db:"checked_in_at" validate:"required"`
+	CheckedInBy string    `db:"checked_in_by" validate:"required"`
+}
+
+func (c PartyCheckIn) ToGRPCPartyCheckIn() *rg.PartyCheckIn {
+	return &rg.PartyCheckIn{
+		PartyId:     c.PartyId,
+		UserId:      c.UserId,
+		CheckedInAt: timestamppb.New(c.CheckedInAt),
+		CheckedInBy: c.CheckedInBy,
+	}
+}
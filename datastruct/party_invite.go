@@ -1,10 +1,32 @@
 package datastruct
 
-import "time"
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
 
 type PartyInvite struct {
 	UserId     string    `db:"user_id"     validate:"required"`
 	InviterId  string    `db:"inviter_id"  validate:"required"`
 	PartyId    string    `db:"party_id"    validate:"required"`
-	ValidUntil time.Time `validate:"required"`
+	ValidUntil time.Time `db:"valid_until" validate:"required"`
+}
+
+func (i PartyInvite) ToGRPCPartyInvite() *rg.PartyInvite {
+	return &rg.PartyInvite{
+		UserId:     i.UserId,
+		InviterId:  i.InviterId,
+		PartyId:    i.PartyId,
+		ValidUntil: timestamppb.New(i.ValidUntil),
+	}
+}
+
+type PartyInviteExpiry struct {
+	ExpiresOn  time.Time `db:"expires_on"  validate:"required"`
+	ValidUntil time.Time `db:"valid_until" validate:"required"`
+	UserId     string    `db:"user_id"     validate:"required"`
+	PartyId    string    `db:"party_id"    validate:"required"`
+	InviterId  string    `db:"inviter_id"  validate:"required"`
 }
@@ -0,0 +1,39 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PartyInviteLink struct {
+	LinkId    string `db:"link_id"    validate:"required"`
+	PartyId   string `db:"party_id"   validate:"required"`
+	CreatorId string `db:"creator_id" validate:"required"`
+	// 0 means the link can be used without limit
+	MaxUses int `db:"max_uses" validate:"gte=0"`
+	Uses    int `db:"uses"     validate:"gte=0"`
+	// Zero if the link doesn't expire
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at" validate:"required"`
+	Revoked   bool      `db:"revoked"`
+}
+
+// The token is the signed link id, it is not stored
+func (l PartyInviteLink) ToGRPCPartyInviteLink(token string) *rg.PartyInviteLink {
+	res := &rg.PartyInviteLink{
+		Token:     token,
+		PartyId:   l.PartyId,
+		CreatorId: l.CreatorId,
+		MaxUses:   uint32(l.MaxUses),
+		Uses:      uint32(l.Uses),
+		CreatedAt: timestamppb.New(l.CreatedAt),
+		Revoked:   l.Revoked,
+	}
+	if !l.ExpiresAt.IsZero() {
+		res.ExpiresAt = timestamppb.New(l.ExpiresAt)
+	}
+
+	return res
+}
@@ -0,0 +1,22 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PartyJoinRequest struct {
+	PartyId     string    `db:"party_id"     validate:"required"`
+	UserId      string    `db:"user_id"      validate:"required"`
+	RequestedAt time.Time `db:"requested_at" validate:"required"`
+}
+
+func (j PartyJoinRequest) ToGRPCPartyJoinRequest() *rg.PartyJoinRequest {
+	return &rg.PartyJoinRequest{
+		PartyId:     j.PartyId,
+		UserId:      j.UserId,
+		RequestedAt: timestamppb.New(j.RequestedAt),
+	}
+}
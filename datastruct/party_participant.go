@@ -1,9 +1,37 @@
 package datastruct
 
-import "time"
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
 
 type PartyParticipant struct {
 	UserId   string    `db:"user_id"    validate:"required"`
 	PartyId  string    `db:"party_id"   validate:"required"`
 	JoinedAt time.Time `db:"joined_at"  validate:"required"`
+	// Guests without an account the participant brings along
+	PlusOnes   int      `db:"plus_ones"   validate:"min=0"`
+	GuestNames []string `db:"guest_names"`
+	// Zero until the participant was checked in at the door
+	CheckedInAt time.Time `db:"checked_in_at"`
+	CheckedInBy string    `db:"checked_in_by"`
+}
+
+func (p PartyParticipant) ToGRPCPartyParticipant() *rg.PartyParticipant {
+	res := &rg.PartyParticipant{
+		UserId:   p.UserId,
+		PartyId:  p.PartyId,
+		JoinedAt: timestamppb.New(p.JoinedAt),
+
+		PlusOnes:   uint32(p.PlusOnes),
+		GuestNames: p.GuestNames,
+	}
+	if !p.CheckedInAt.IsZero() {
+		res.CheckedInAt = timestamppb.New(p.CheckedInAt)
+		res.CheckedInBy = p.CheckedInBy
+	}
+
+	return res
 }
@@ -0,0 +1,9 @@
+package datastruct
+
+type PartyParticipantCount struct {
+	PartyId          string `db:"party_id"`
+	ParticipantCount int64  `db:"participant_count"`
+	// Plus-ones of all participants
+	GuestCount     int64 `db:"guest_count"`
+	CheckedInCount int64 `db:"checked_in_count"`
+}
@@ -0,0 +1,58 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Participants without a stored role are guests
+const (
+	ROLE_HOST    string = "host"
+	ROLE_CO_HOST string = "co_host"
+)
+
+type PartyRole struct {
+	PartyId    string    `db:"party_id"    validate:"required"`
+	UserId     string    `db:"user_id"     validate:"required"`
+	Role       string    `db:"role"        validate:"required,oneof=host co_host"`
+	AssignedAt time.Time `db:"assigned_at" validate:"required"`
+}
+
+func (r PartyRole) ToGRPCPartyRole() *rg.PartyRole {
+	return &rg.PartyRole{
+		PartyId:    r.PartyId,
+		UserId:     r.UserId,
+		Role:       RoleToGRPC(r.Role),
+		AssignedAt: timestamppb.New(r.AssignedAt),
+	}
+}
+
+// Returns true if the user may manage the party, e.g. invite or remove participants
+func (r PartyRole) IsHost() bool {
+	return r.Role == ROLE_HOST || r.Role == ROLE_CO_HOST
+}
+
+func RoleToGRPC(r string) rg.Role {
+	switch r {
+	case ROLE_HOST:
+		return rg.Role_HOST
+	case ROLE_CO_HOST:
+		return rg.Role_CO_HOST
+	default:
+		return rg.Role_GUEST
+	}
+}
+
+// Returns an empty string for guests, which have no stored role
+func RoleFromGRPC(r rg.Role) string {
+	switch r {
+	case rg.Role_HOST:
+		return ROLE_HOST
+	case rg.Role_CO_HOST:
+		return ROLE_CO_HOST
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,63 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	RSVP_GOING      string = "going"
+	RSVP_INTERESTED string = "interested"
+	RSVP_NOT_GOING  string = "not_going"
+)
+
+type PartyRsvp struct {
+	PartyId   string    `db:"party_id"   validate:"required"`
+	UserId    string    `db:"user_id"    validate:"required"`
+	Status    string    `db:"status"     validate:"required,oneof=going interested not_going"`
+	UpdatedAt time.Time `db:"updated_at" validate:"required"`
+}
+
+func (r PartyRsvp) ToGRPCPartyRsvp() *rg.PartyRsvp {
+	return &rg.PartyRsvp{
+		PartyId:   r.PartyId,
+		UserId:    r.UserId,
+		Status:    RsvpStatusToGRPC(r.Status),
+		UpdatedAt: timestamppb.New(r.UpdatedAt),
+	}
+}
+
+func RsvpStatusToGRPC(s string) rg.RsvpStatus {
+	switch s {
+	case RSVP_GOING:
+		return rg.RsvpStatus_GOING
+	case RSVP_INTERESTED:
+		return rg.RsvpStatus_INTERESTED
+	case RSVP_NOT_GOING:
+		return rg.RsvpStatus_NOT_GOING
+	default:
+		return rg.RsvpStatus_UNKNOWN
+	}
+}
+
+// Returns an empty string for unknown statuses
+func RsvpStatusFromGRPC(s rg.RsvpStatus) string {
+	switch s {
+	case rg.RsvpStatus_GOING:
+		return RSVP_GOING
+	case rg.RsvpStatus_INTERESTED:
+		return RSVP_INTERESTED
+	case rg.RsvpStatus_NOT_GOING:
+		return RSVP_NOT_GOING
+	default:
+		return ""
+	}
+}
+
+type PartyRsvpCount struct {
+	PartyId   string `db:"party_id"`
+	Status    string `db:"status"`
+	RsvpCount int64  `db:"rsvp_count"`
+}
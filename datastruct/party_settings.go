@@ -0,0 +1,25 @@
+package datastruct
+
+import rg "github.com/clubo-app/protobuf/relation"
+
+type PartySettings struct {
+	PartyId  string `db:"party_id" validate:"required"`
+	Capacity int    `db:"capacity" validate:"min=0"`
+	Private  bool   `db:"private"`
+	// Allows guests to invite others, otherwise only hosts can invite
+	AllowGuestInvites bool `db:"allow_guest_invites"`
+	MaxPlusOnes       int  `db:"max_plus_ones" validate:"min=0"`
+	// Seats reserved by participants and their plus-ones, only changed through lightweight transactions
+	TakenSeats int `db:"taken_seats"`
+}
+
+func (s PartySettings) ToGRPCPartySettings() *rg.PartySettings {
+	return &rg.PartySettings{
+		PartyId:  s.PartyId,
+		Capacity: uint32(s.Capacity),
+		Private:  s.Private,
+
+		AllowGuestInvites: s.AllowGuestInvites,
+		MaxPlusOnes:       uint32(s.MaxPlusOnes),
+	}
+}
@@ -0,0 +1,22 @@
+package datastruct
+
+import (
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WaitlistEntry struct {
+	PartyId  string    `db:"party_id"  validate:"required"`
+	UserId   string    `db:"user_id"   validate:"required"`
+	QueuedAt time.Time `db:"queued_at" validate:"required"`
+}
+
+func (w WaitlistEntry) ToGRPCWaitlistEntry() *rg.WaitlistEntry {
+	return &rg.WaitlistEntry{
+		PartyId:  w.PartyId,
+		UserId:   w.UserId,
+		QueuedAt: timestamppb.New(w.QueuedAt),
+	}
+}
@@ -4,12 +4,17 @@ import (
 	"log"
 
 	"github.com/clubo-app/packages/stream"
+	pg "github.com/clubo-app/protobuf/party"
 	"github.com/clubo-app/relation-service/config"
 	"github.com/clubo-app/relation-service/consumer"
 	"github.com/clubo-app/relation-service/repository"
 	"github.com/clubo-app/relation-service/rpc"
+	"github.com/clubo-app/relation-service/sweeper"
+	"github.com/clubo-app/relation-service/token"
 	"github.com/go-playground/validator/v10"
 	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
@@ -34,12 +39,37 @@ func main() {
 	dao := repository.NewDAO(cqlx)
 	val := validator.New()
 
-	fs := dao.NewFriendRelationRepository(val)
+	fs := dao.NewFriendRelationRepository(val, c.FRIEND_REQUEST_TTL)
+	cs := dao.NewCloseFriendRepository(val)
+	bs := dao.NewBlockRepository(val)
+	gs := dao.NewFriendGroupRepository(val)
+	fas := dao.NewFriendActivityRepository(val)
 	ps := dao.NewFavoritePartyRepository(val)
+	pcs := dao.NewFavoriteCollectionRepository(val)
+	pps := dao.NewPartyParticipantsRepository(val)
+	pss := dao.NewPartySettingsRepository(val)
+	prs := dao.NewPartyRsvpRepository(val)
+	pro := dao.NewPartyRoleRepository(val)
+	pls := dao.NewPartyInviteLinkRepository(val)
 
-	con := consumer.New(stream, fs, ps)
+	con := consumer.New(stream, fs, ps, pcs, pps, pss, prs, pro, fas, pls)
 	go con.Start()
 
-	r := rpc.NewRelationServer(fs, ps, stream)
+	sw := sweeper.New(stream, fs, pps, ps, c.SWEEP_INTERVAL)
+	go sw.Start()
+
+	tk, err := token.NewSigner(c.TOKEN_SECRET)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	conn, err := grpc.Dial(c.PARTY_SERVICE_ADDRESS, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer conn.Close()
+	pc := pg.NewPartyServiceClient(conn)
+
+	r := rpc.NewRelationServer(fs, cs, bs, gs, fas, ps, pcs, pps, pss, prs, pro, pls, tk, pc, stream)
 	rpc.Start(r, c.PORT)
 }
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+const (
+	USER_BLOCKS string = "user_blocks"
+)
+
+var blockMetadata = table.Metadata{
+	Name:    USER_BLOCKS,
+	Columns: []string{"user_id", "blocked_id", "blocked_at"},
+	PartKey: []string{"user_id"},
+	SortKey: []string{"blocked_id"},
+}
+
+type BlockRepository interface {
+	Block(ctx context.Context, uId, bId string) (datastruct.Block, error)
+	Unblock(ctx context.Context, uId, bId string) error
+	GetBlocks(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.Block, []byte, error)
+	IsBlocked(ctx context.Context, uId, oId string) (bool, error)
+}
+
+type blockRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+func (r *blockRepository) Block(ctx context.Context, uId, bId string) (datastruct.Block, error) {
+	b := datastruct.Block{
+		UserId:    uId,
+		BlockedId: bId,
+		BlockedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, b)
+	if err != nil {
+		return datastruct.Block{}, err
+	}
+
+	stmt, names := qb.
+		Insert(USER_BLOCKS).
+		Columns(blockMetadata.Columns...).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(b).
+		ExecRelease()
+	if err != nil {
+		return datastruct.Block{}, err
+	}
+
+	return b, nil
+}
+
+func (r *blockRepository) Unblock(ctx context.Context, uId, bId string) error {
+	stmt, names := qb.
+		Delete(USER_BLOCKS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("blocked_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":    uId,
+			"blocked_id": bId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *blockRepository) GetBlocks(ctx context.Context, uId string, page []byte, limit uint64) (res []datastruct.Block, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(USER_BLOCKS).
+		Columns(blockMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"user_id": uId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.Block{}, nil, errors.New("no blocked users found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+// Returns true if either of the users blocked the other one
+func (r *blockRepository) IsBlocked(ctx context.Context, uId, oId string) (bool, error) {
+	stmt, names := qb.
+		Select(USER_BLOCKS).
+		Columns(blockMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("blocked_id")).
+		ToCql()
+
+	for _, m := range []qb.M{
+		{"user_id": uId, "blocked_id": oId},
+		{"user_id": oId, "blocked_id": uId},
+	} {
+		var b datastruct.Block
+		err := r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindMap(m).
+			GetRelease(&b)
+		if err == gocql.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+const (
+	CLOSE_FRIENDS string = "close_friends"
+)
+
+var closeFriendMetadata = table.Metadata{
+	Name:    CLOSE_FRIENDS,
+	Columns: []string{"user_id", "friend_id", "added_at"},
+	PartKey: []string{"user_id"},
+	SortKey: []string{"friend_id"},
+}
+
+type CloseFriendRepository interface {
+	AddCloseFriend(ctx context.Context, uId, fId string) (datastruct.CloseFriend, error)
+	RemoveCloseFriend(ctx context.Context, uId, fId string) error
+	GetCloseFriends(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.CloseFriend, []byte, error)
+	IsCloseFriend(ctx context.Context, uId, fId string) (bool, error)
+}
+
+type closeFriendRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+func (r *closeFriendRepository) AddCloseFriend(ctx context.Context, uId, fId string) (datastruct.CloseFriend, error) {
+	cf := datastruct.CloseFriend{
+		UserId:   uId,
+		FriendId: fId,
+		AddedAt:  time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, cf)
+	if err != nil {
+		return datastruct.CloseFriend{}, err
+	}
+
+	stmt, names := qb.
+		Insert(CLOSE_FRIENDS).
+		Columns(closeFriendMetadata.Columns...).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(cf).
+		ExecRelease()
+	if err != nil {
+		return datastruct.CloseFriend{}, err
+	}
+
+	return cf, nil
+}
+
+func (r *closeFriendRepository) RemoveCloseFriend(ctx context.Context, uId, fId string) error {
+	stmt, names := qb.
+		Delete(CLOSE_FRIENDS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("friend_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":   uId,
+			"friend_id": fId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *closeFriendRepository) GetCloseFriends(ctx context.Context, uId string, page []byte, limit uint64) (res []datastruct.CloseFriend, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(CLOSE_FRIENDS).
+		Columns(closeFriendMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"user_id": uId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.CloseFriend{}, nil, errors.New("no close friends found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r *closeFriendRepository) IsCloseFriend(ctx context.Context, uId, fId string) (bool, error) {
+	stmt, names := qb.
+		Select(CLOSE_FRIENDS).
+		Columns(closeFriendMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("friend_id")).
+		ToCql()
+
+	var cf datastruct.CloseFriend
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":   uId,
+			"friend_id": fId,
+		})).
+		GetRelease(&cf)
+	if err == gocql.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
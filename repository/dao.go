@@ -2,6 +2,7 @@ package repository
 
 import (
 	"strings"
+	"time"
 
 	"github.com/clubo-app/packages/cqlx"
 	"github.com/go-playground/validator/v10"
@@ -32,10 +33,50 @@ func NewDAO(sess *gocqlx.Session) dao {
 	return dao{sess: sess}
 }
 
-func (d *dao) NewFriendRelationRepository(val *validator.Validate) FriendRelationRepository {
-	return &friendRelationRepository{sess: d.sess, val: val}
+func (d *dao) NewFriendRelationRepository(val *validator.Validate, requestTTL time.Duration) FriendRelationRepository {
+	return &friendRelationRepository{sess: d.sess, val: val, requestTTL: requestTTL}
+}
+
+func (d *dao) NewCloseFriendRepository(val *validator.Validate) CloseFriendRepository {
+	return &closeFriendRepository{sess: d.sess, val: val}
+}
+
+func (d *dao) NewBlockRepository(val *validator.Validate) BlockRepository {
+	return &blockRepository{sess: d.sess, val: val}
+}
+
+func (d *dao) NewFriendGroupRepository(val *validator.Validate) FriendGroupRepository {
+	return &friendGroupRepository{sess: d.sess, val: val}
+}
+
+func (d *dao) NewFriendActivityRepository(val *validator.Validate) FriendActivityRepository {
+	return &friendActivityRepository{sess: d.sess, val: val}
 }
 
 func (d *dao) NewFavoritePartyRepository(val *validator.Validate) FavoritePartyRepository {
 	return &favoritePartyRepository{sess: d.sess, val: val}
 }
+
+func (d *dao) NewFavoriteCollectionRepository(val *validator.Validate) FavoriteCollectionRepository {
+	return &favoriteCollectionRepository{sess: d.sess, val: val}
+}
+
+func (d *dao) NewPartyRsvpRepository(val *validator.Validate) PartyRsvpRepository {
+	return &partyRsvpRepository{sess: d.sess, val: val}
+}
+
+func (d *dao) NewPartyRoleRepository(val *validator.Validate) PartyRoleRepository {
+	return &partyRoleRepository{sess: d.sess, val: val}
+}
+
+func (d *dao) NewPartyInviteLinkRepository(val *validator.Validate) PartyInviteLinkRepository {
+	return &partyInviteLinkRepository{sess: d.sess, val: val}
+}
+
+func (d *dao) NewPartySettingsRepository(val *validator.Validate) PartySettingsRepository {
+	return &partySettingsRepository{sess: d.sess, val: val}
+}
+
+func (d *dao) NewPartyParticipantsRepository(val *validator.Validate) PartyParticipantsRepository {
+	return &partyParticipantRepository{sess: d.sess, val: val}
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	FAVORITE_COLLECTIONS                 string = "favorite_collections"
+	FAVORITE_COLLECTION_PARTIES          string = "favorite_collection_parties"
+	FAVORITE_COLLECTION_PARTIES_BY_PARTY string = "favorite_collection_parties_by_party"
+)
+
+var favoriteCollectionMetadata = table.Metadata{
+	Name:    FAVORITE_COLLECTIONS,
+	Columns: []string{"user_id", "collection_id", "name", "created_at"},
+	PartKey: []string{"user_id"},
+	SortKey: []string{"collection_id"},
+}
+
+var favoriteCollectionPartyMetadata = table.Metadata{
+	Name:    FAVORITE_COLLECTION_PARTIES,
+	Columns: []string{"collection_id", "party_id", "user_id", "added_at"},
+	PartKey: []string{"collection_id"},
+	SortKey: []string{"party_id"},
+}
+
+type FavoriteCollectionRepository interface {
+	CreateCollection(ctx context.Context, uId, name string) (datastruct.FavoriteCollection, error)
+	RenameCollection(ctx context.Context, uId, cId, name string) (datastruct.FavoriteCollection, error)
+	DeleteCollection(ctx context.Context, uId, cId string) error
+	GetCollection(ctx context.Context, uId, cId string) (datastruct.FavoriteCollection, error)
+	GetCollections(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FavoriteCollection, []byte, error)
+	AddCollectionParty(ctx context.Context, uId, cId, pId string) (datastruct.FavoriteCollectionParty, error)
+	RemoveCollectionParty(ctx context.Context, cId, pId string) error
+	RemovePartyFromCollections(ctx context.Context, uId, pId string) error
+	GetCollectionParties(ctx context.Context, cId string, page []byte, limit uint64) ([]datastruct.FavoriteCollectionParty, []byte, error)
+	GetPartyCollections(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.FavoriteCollectionParty, []byte, error)
+	RemoveCollectionParties(ctx context.Context, pId string, cIds []string) error
+}
+
+type favoriteCollectionRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+func (r *favoriteCollectionRepository) CreateCollection(ctx context.Context, uId, name string) (datastruct.FavoriteCollection, error) {
+	c := datastruct.FavoriteCollection{
+		UserId:       uId,
+		CollectionId: ksuid.New().String(),
+		Name:         name,
+		CreatedAt:    time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, c)
+	if err != nil {
+		return datastruct.FavoriteCollection{}, err
+	}
+
+	stmt, names := qb.
+		Insert(FAVORITE_COLLECTIONS).
+		Columns(favoriteCollectionMetadata.Columns...).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(c).
+		ExecRelease()
+	if err != nil {
+		return datastruct.FavoriteCollection{}, err
+	}
+
+	return c, nil
+}
+
+func (r *favoriteCollectionRepository) RenameCollection(ctx context.Context, uId, cId, name string) (datastruct.FavoriteCollection, error) {
+	c, err := r.GetCollection(ctx, uId, cId)
+	if err != nil {
+		return datastruct.FavoriteCollection{}, err
+	}
+	c.Name = name
+
+	err = r.val.StructCtx(ctx, c)
+	if err != nil {
+		return datastruct.FavoriteCollection{}, err
+	}
+
+	stmt, names := qb.
+		Update(FAVORITE_COLLECTIONS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("collection_id")).
+		Set("name").
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(c).
+		ExecRelease()
+	if err != nil {
+		return datastruct.FavoriteCollection{}, err
+	}
+
+	return c, nil
+}
+
+// Deleting a collection keeps its parties favorited
+func (r *favoriteCollectionRepository) DeleteCollection(ctx context.Context, uId, cId string) error {
+	stmt, names := qb.
+		Delete(FAVORITE_COLLECTIONS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("collection_id")).
+		Existing().
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":       uId,
+			"collection_id": cId,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return status.Error(codes.NotFound, "Collection not found")
+	}
+
+	// favorite_collection_parties_by_party is a view of the parties, so they are deleted one by one instead of the whole partition
+	var page []byte
+	for {
+		ps, next, err := r.GetCollectionParties(ctx, cId, page, 100)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range ps {
+			err = r.RemoveCollectionParty(ctx, cId, p.PartyId)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(next) == 0 {
+			return nil
+		}
+		page = next
+	}
+}
+
+func (r *favoriteCollectionRepository) GetCollection(ctx context.Context, uId, cId string) (res datastruct.FavoriteCollection, err error) {
+	stmt, names := qb.
+		Select(FAVORITE_COLLECTIONS).
+		Columns(favoriteCollectionMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("collection_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":       uId,
+			"collection_id": cId,
+		})).
+		GetRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r *favoriteCollectionRepository) GetCollections(ctx context.Context, uId string, page []byte, limit uint64) (res []datastruct.FavoriteCollection, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FAVORITE_COLLECTIONS).
+		Columns(favoriteCollectionMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"user_id": uId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FavoriteCollection{}, nil, errors.New("no collections found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r *favoriteCollectionRepository) AddCollectionParty(ctx context.Context, uId, cId, pId string) (datastruct.FavoriteCollectionParty, error) {
+	p := datastruct.FavoriteCollectionParty{
+		CollectionId: cId,
+		PartyId:      pId,
+		UserId:       uId,
+		AddedAt:      time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, p)
+	if err != nil {
+		return datastruct.FavoriteCollectionParty{}, err
+	}
+
+	stmt, names := qb.
+		Insert(FAVORITE_COLLECTION_PARTIES).
+		Columns(favoriteCollectionPartyMetadata.Columns...).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(p).
+		ExecRelease()
+	if err != nil {
+		return datastruct.FavoriteCollectionParty{}, err
+	}
+
+	return p, nil
+}
+
+func (r *favoriteCollectionRepository) RemoveCollectionParty(ctx context.Context, cId, pId string) error {
+	stmt, names := qb.
+		Delete(FAVORITE_COLLECTION_PARTIES).
+		Where(qb.Eq("collection_id")).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"collection_id": cId,
+			"party_id":      pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Removes the party from every collection of the user, used when the party is unfavorited
+func (r *favoriteCollectionRepository) RemovePartyFromCollections(ctx context.Context, uId, pId string) error {
+	var page []byte
+	for {
+		cs, next, err := r.GetCollections(ctx, uId, page, 100)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cs {
+			err = r.RemoveCollectionParty(ctx, c.CollectionId, pId)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(next) == 0 {
+			return nil
+		}
+		page = next
+	}
+}
+
+func (r *favoriteCollectionRepository) GetCollectionParties(ctx context.Context, cId string, page []byte, limit uint64) (res []datastruct.FavoriteCollectionParty, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FAVORITE_COLLECTION_PARTIES).
+		Columns(favoriteCollectionPartyMetadata.Columns...).
+		Where(qb.Eq("collection_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"collection_id": cId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FavoriteCollectionParty{}, nil, errors.New("no collection parties found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r *favoriteCollectionRepository) GetPartyCollections(ctx context.Context, pId string, page []byte, limit uint64) (res []datastruct.FavoriteCollectionParty, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FAVORITE_COLLECTION_PARTIES_BY_PARTY).
+		Columns(favoriteCollectionPartyMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FavoriteCollectionParty{}, nil, errors.New("no party collections found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+// Removes the party from the given collections, each collection is its own partition so the deletes are batched
+func (r *favoriteCollectionRepository) RemoveCollectionParties(ctx context.Context, pId string, cIds []string) error {
+	if len(cIds) == 0 {
+		return nil
+	}
+
+	b := qb.Batch()
+	m := qb.M{}
+	for n, cId := range cIds {
+		cp := fmt.Sprintf("party%d", n)
+		b.AddWithPrefix(cp, qb.
+			Delete(FAVORITE_COLLECTION_PARTIES).
+			Where(qb.Eq("collection_id")).
+			Where(qb.Eq("party_id")))
+		m[cp+".collection_id"] = cId
+		m[cp+".party_id"] = pId
+	}
+
+	stmt, names := b.ToCql()
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap(m).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
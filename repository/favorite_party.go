@@ -3,18 +3,23 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/clubo-app/relation-service/datastruct"
 	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
 	"github.com/scylladb/gocqlx/v2"
 	"github.com/scylladb/gocqlx/v2/qb"
 	"github.com/scylladb/gocqlx/v2/table"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	FAVORITE_PARTIES         string = "favorite_parties"
 	FAVORITE_PARTIES_BY_USER string = "favorite_parties_by_user"
 	FAVORITE_PARTY_COUNT     string = "favorite_party_count"
+	FAVORITE_PARTY_HOURLY    string = "favorite_party_hourly_count"
 )
 
 var favoritePartyMetadata = table.Metadata{
@@ -27,16 +32,30 @@ var favoritePartyCountMetadata = table.Metadata{
 	Columns: []string{"party_id", "favorite_party_count"},
 	PartKey: []string{"party_id"},
 }
+var favoritePartyHourlyMetadata = table.Metadata{
+	Name:    FAVORITE_PARTY_HOURLY,
+	Columns: []string{"hour", "party_id", "favorite_count"},
+	PartKey: []string{"hour"},
+	SortKey: []string{"party_id"},
+}
 
 type FavoritePartyRepository interface {
 	FavorParty(ctx context.Context, fp datastruct.FavoriteParty) (datastruct.FavoriteParty, error)
-	DefavorParty(ctx context.Context, uId, pId string) error
+	DefavorParty(ctx context.Context, uId, pId string) (datastruct.FavoriteParty, error)
 	GetFavoritePartiesByUser(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FavoriteParty, []byte, error)
 	GetFavorisingUsersByParty(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.FavoriteParty, []byte, error)
+	GetFavorisingUsersIn(ctx context.Context, pId string, uIds []string) ([]datastruct.FavoriteParty, error)
+	GetFavoritesIn(ctx context.Context, uId string, pIds []string) ([]datastruct.FavoriteParty, error)
 	GetfavoritePartyCount(ctx context.Context, pId string) (datastruct.FavoritePartyCount, error)
 	GetManyfavoritePartyCount(ctx context.Context, pIds []string) ([]datastruct.FavoritePartyCount, error)
 	IncreaseFavoritePartyCount(ctx context.Context, pId string) error
 	DecreaseFavoritePartyCount(ctx context.Context, pId string) error
+	IncreaseHourlyFavoriteCount(ctx context.Context, pId string) error
+	DecreaseHourlyFavoriteCount(ctx context.Context, pId string, favoritedAt time.Time) error
+	GetHourlyFavoriteCounts(ctx context.Context, hour time.Time) ([]datastruct.HourlyFavoriteCount, error)
+	DeleteHourlyFavoriteCounts(ctx context.Context, hour time.Time) error
+	DeleteFavoriteCounts(ctx context.Context, pId string) error
+	RemoveFavorites(ctx context.Context, pId string, uIds []string) error
 }
 
 type favoritePartyRepository struct {
@@ -67,23 +86,45 @@ func (r *favoritePartyRepository) FavorParty(ctx context.Context, fp datastruct.
 	return fp, nil
 }
 
-func (r *favoritePartyRepository) DefavorParty(ctx context.Context, uId, pId string) error {
+// Returns the removed favorite, its favorited_at tells which hourly bucket it was counted in
+func (r *favoritePartyRepository) DefavorParty(ctx context.Context, uId, pId string) (res datastruct.FavoriteParty, err error) {
 	stmt, names := qb.
-		Delete(FAVORITE_PARTIES).
+		Select(FAVORITE_PARTIES).
 		Where(qb.Eq("user_id")).
 		Where(qb.Eq("party_id")).
-		Existing().
 		ToCql()
 
-	err := r.sess.
-		Query(stmt, names).
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
 		BindMap((qb.M{"party_id": pId, "user_id": uId})).
-		ExecRelease()
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return datastruct.FavoriteParty{}, status.Error(codes.NotFound, "Favorite Party not found")
+	}
 	if err != nil {
-		return err
+		return datastruct.FavoriteParty{}, err
 	}
 
-	return nil
+	// Only the favorite that was read is deleted, a concurrent refavor keeps its own favorited_at
+	stmt, names = qb.
+		Delete(FAVORITE_PARTIES).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("party_id")).
+		If(qb.Eq("favorited_at")).
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(res).
+		ExecCASRelease()
+	if err != nil {
+		return datastruct.FavoriteParty{}, err
+	}
+	if !applied {
+		return datastruct.FavoriteParty{}, status.Error(codes.NotFound, "Favorite Party not found")
+	}
+
+	return res, nil
 }
 
 func (r *favoritePartyRepository) GetFavoritePartiesByUser(ctx context.Context, uId string, page []byte, limit uint64) (result []datastruct.FavoriteParty, nextPage []byte, err error) {
@@ -140,6 +181,61 @@ func (r *favoritePartyRepository) GetFavorisingUsersByParty(ctx context.Context,
 	return result, iter.PageState(), nil
 }
 
+// Returns which of the users favorited the party
+func (r *favoritePartyRepository) GetFavorisingUsersIn(ctx context.Context, pId string, uIds []string) (result []datastruct.FavoriteParty, err error) {
+	if len(uIds) == 0 {
+		return result, nil
+	}
+
+	stmt, names := qb.
+		Select(FAVORITE_PARTIES).
+		Columns(favoritePartyMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		Where(qb.In("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uIds,
+		})).
+		SelectRelease(&result)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Returns which of the parties the user favorited.
+// favorite_parties is partitioned by party, so this is one query over the given partitions.
+func (r *favoritePartyRepository) GetFavoritesIn(ctx context.Context, uId string, pIds []string) (result []datastruct.FavoriteParty, err error) {
+	if len(pIds) == 0 {
+		return result, nil
+	}
+
+	stmt, names := qb.
+		Select(FAVORITE_PARTIES).
+		Columns(favoritePartyMetadata.Columns...).
+		Where(qb.In("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pIds,
+			"user_id":  uId,
+		})).
+		SelectRelease(&result)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
 func (r *favoritePartyRepository) GetfavoritePartyCount(ctx context.Context, pId string) (res datastruct.FavoritePartyCount, err error) {
 	stmt, names := qb.
 		Select(FAVORITE_PARTY_COUNT).
@@ -214,3 +310,168 @@ func (r *favoritePartyRepository) DecreaseFavoritePartyCount(ctx context.Context
 	}
 	return nil
 }
+
+// Longest window over which hourly favorite counts are read
+const MaxHourlyFavoriteWindow = 7 * 24 * time.Hour
+
+// Counters can't expire by ttl, so buckets are deleted once they are a day past the window.
+// Decrements skip buckets outside of the window, so a deleted counter is never updated again.
+const HourlyFavoriteRetention = MaxHourlyFavoriteWindow + 24*time.Hour
+
+// Returns the hourly bucket the time falls into
+func favoriteHour(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+func (r *favoritePartyRepository) IncreaseHourlyFavoriteCount(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Update(FAVORITE_PARTY_HOURLY).
+		Where(qb.Eq("hour")).
+		Where(qb.Eq("party_id")).
+		Add("favorite_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"favorite_count": 1,
+			"hour":           favoriteHour(time.Now()),
+			"party_id":       pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Takes the favorite back from the bucket it was counted in, buckets outside of the window aren't read anymore so they are left alone
+func (r *favoritePartyRepository) DecreaseHourlyFavoriteCount(ctx context.Context, pId string, favoritedAt time.Time) error {
+	hour := favoriteHour(favoritedAt)
+	if time.Since(hour) >= MaxHourlyFavoriteWindow {
+		return nil
+	}
+
+	stmt, names := qb.
+		Update(FAVORITE_PARTY_HOURLY).
+		Where(qb.Eq("hour")).
+		Where(qb.Eq("party_id")).
+		Remove("favorite_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"favorite_count": 1,
+			"hour":           hour,
+			"party_id":       pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Returns the counts of all parties favorited or unfavorited in the hour of the given time
+func (r *favoritePartyRepository) GetHourlyFavoriteCounts(ctx context.Context, hour time.Time) (res []datastruct.HourlyFavoriteCount, err error) {
+	stmt, names := qb.
+		Select(FAVORITE_PARTY_HOURLY).
+		Columns(favoritePartyHourlyMetadata.Columns...).
+		Where(qb.Eq("hour")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"hour": favoriteHour(hour)})).
+		SelectRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Deletes the whole partition of the hour, favorite_party_hourly_count has no views so a partition delete is safe
+func (r *favoritePartyRepository) DeleteHourlyFavoriteCounts(ctx context.Context, hour time.Time) error {
+	stmt, names := qb.
+		Delete(FAVORITE_PARTY_HOURLY).
+		Where(qb.Eq("hour")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"hour": favoriteHour(hour)})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Deletes the all-time count and the hourly buckets of the party which are still within the window,
+// older buckets are dropped by the sweeper anyway
+func (r *favoritePartyRepository) DeleteFavoriteCounts(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Delete(FAVORITE_PARTY_COUNT).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	var hours []time.Time
+	now := favoriteHour(time.Now())
+	for h := now; now.Sub(h) < MaxHourlyFavoriteWindow; h = h.Add(-time.Hour) {
+		hours = append(hours, h)
+	}
+
+	stmt, names = qb.
+		Delete(FAVORITE_PARTY_HOURLY).
+		Where(qb.In("hour")).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"hour":     hours,
+			"party_id": pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *favoritePartyRepository) RemoveFavorites(ctx context.Context, pId string, uIds []string) error {
+	if len(uIds) == 0 {
+		return nil
+	}
+
+	stmt, names := qb.
+		Delete(FAVORITE_PARTIES).
+		Where(qb.Eq("party_id")).
+		Where(qb.In("user_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uIds,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
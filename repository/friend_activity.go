@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	FRIEND_ACTIVITY_FEED     string = "friend_activity_feed"
+	FRIEND_ACTIVITY_BY_PARTY string = "friend_activity_by_party"
+)
+
+var friendActivityMetadata = table.Metadata{
+	Name:    FRIEND_ACTIVITY_FEED,
+	Columns: []string{"user_id", "activity_id", "actor_id", "party_id", "type", "created_at"},
+	PartKey: []string{"user_id"},
+	SortKey: []string{"activity_id"},
+}
+
+// Feed entries are trimmed by expiring them, old activities aren't interesting anymore
+const feedEntryTTL = 30 * 24 * time.Hour
+
+type FriendActivityRepository interface {
+	FanOut(ctx context.Context, uIds []string, actorId, pId, activityType string) error
+	GetFeed(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendActivity, []byte, error)
+	GetPartyActivities(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.FriendActivity, []byte, error)
+	RemoveActivities(ctx context.Context, as []datastruct.FriendActivity) error
+}
+
+type friendActivityRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+// Writes the activity of the actor into the feeds of all given users in one batch
+func (r *friendActivityRepository) FanOut(ctx context.Context, uIds []string, actorId, pId, activityType string) error {
+	if len(uIds) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	aId := ksuid.New().String()
+
+	b := qb.Batch()
+	m := qb.M{}
+	for n, uId := range uIds {
+		a := datastruct.FriendActivity{
+			UserId:     uId,
+			ActivityId: aId,
+			ActorId:    actorId,
+			PartyId:    pId,
+			Type:       activityType,
+			CreatedAt:  now,
+		}
+		err := r.val.StructCtx(ctx, a)
+		if err != nil {
+			return err
+		}
+
+		prefix := fmt.Sprintf("feed%d", n)
+		b.AddWithPrefix(prefix, qb.
+			Insert(FRIEND_ACTIVITY_FEED).
+			Columns(friendActivityMetadata.Columns...).
+			TTL(feedEntryTTL))
+
+		m[prefix+".user_id"] = a.UserId
+		m[prefix+".activity_id"] = a.ActivityId
+		m[prefix+".actor_id"] = a.ActorId
+		m[prefix+".party_id"] = a.PartyId
+		m[prefix+".type"] = a.Type
+		m[prefix+".created_at"] = a.CreatedAt
+	}
+
+	stmt, names := b.ToCql()
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap(m).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *friendActivityRepository) GetFeed(ctx context.Context, uId string, page []byte, limit uint64) (res []datastruct.FriendActivity, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FRIEND_ACTIVITY_FEED).
+		Columns(friendActivityMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"user_id": uId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FriendActivity{}, nil, errors.New("no activities found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r *friendActivityRepository) GetPartyActivities(ctx context.Context, pId string, page []byte, limit uint64) (res []datastruct.FriendActivity, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FRIEND_ACTIVITY_BY_PARTY).
+		Columns(friendActivityMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FriendActivity{}, nil, errors.New("no activities found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+// Removes the activities from the feeds they were fanned out to in one batch
+func (r *friendActivityRepository) RemoveActivities(ctx context.Context, as []datastruct.FriendActivity) error {
+	if len(as) == 0 {
+		return nil
+	}
+
+	b := qb.Batch()
+	m := qb.M{}
+	for n, a := range as {
+		prefix := fmt.Sprintf("feed%d", n)
+		b.AddWithPrefix(prefix, qb.
+			Delete(FRIEND_ACTIVITY_FEED).
+			Where(qb.Eq("user_id")).
+			Where(qb.Eq("activity_id")))
+
+		m[prefix+".user_id"] = a.UserId
+		m[prefix+".activity_id"] = a.ActivityId
+	}
+
+	stmt, names := b.ToCql()
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap(m).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
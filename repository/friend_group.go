@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	FRIEND_GROUPS        string = "friend_groups"
+	FRIEND_GROUP_MEMBERS string = "friend_group_members"
+)
+
+var friendGroupMetadata = table.Metadata{
+	Name:    FRIEND_GROUPS,
+	Columns: []string{"user_id", "group_id", "name", "created_at"},
+	PartKey: []string{"user_id"},
+	SortKey: []string{"group_id"},
+}
+
+var friendGroupMemberMetadata = table.Metadata{
+	Name:    FRIEND_GROUP_MEMBERS,
+	Columns: []string{"group_id", "user_id", "added_at"},
+	PartKey: []string{"group_id"},
+	SortKey: []string{"user_id"},
+}
+
+type FriendGroupRepository interface {
+	CreateGroup(ctx context.Context, uId, name string) (datastruct.FriendGroup, error)
+	RenameGroup(ctx context.Context, uId, gId, name string) (datastruct.FriendGroup, error)
+	DeleteGroup(ctx context.Context, uId, gId string) error
+	GetGroup(ctx context.Context, uId, gId string) (datastruct.FriendGroup, error)
+	GetGroups(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendGroup, []byte, error)
+	AddGroupMember(ctx context.Context, gId, mId string) (datastruct.FriendGroupMember, error)
+	RemoveGroupMember(ctx context.Context, gId, mId string) error
+	GetGroupMembers(ctx context.Context, gId string, page []byte, limit uint64) ([]datastruct.FriendGroupMember, []byte, error)
+}
+
+type friendGroupRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+func (r *friendGroupRepository) CreateGroup(ctx context.Context, uId, name string) (datastruct.FriendGroup, error) {
+	g := datastruct.FriendGroup{
+		UserId:    uId,
+		GroupId:   ksuid.New().String(),
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, g)
+	if err != nil {
+		return datastruct.FriendGroup{}, err
+	}
+
+	stmt, names := qb.
+		Insert(FRIEND_GROUPS).
+		Columns(friendGroupMetadata.Columns...).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(g).
+		ExecRelease()
+	if err != nil {
+		return datastruct.FriendGroup{}, err
+	}
+
+	return g, nil
+}
+
+func (r *friendGroupRepository) RenameGroup(ctx context.Context, uId, gId, name string) (datastruct.FriendGroup, error) {
+	g, err := r.GetGroup(ctx, uId, gId)
+	if err != nil {
+		return datastruct.FriendGroup{}, err
+	}
+	g.Name = name
+
+	err = r.val.StructCtx(ctx, g)
+	if err != nil {
+		return datastruct.FriendGroup{}, err
+	}
+
+	stmt, names := qb.
+		Update(FRIEND_GROUPS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("group_id")).
+		Set("name").
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(g).
+		ExecRelease()
+	if err != nil {
+		return datastruct.FriendGroup{}, err
+	}
+
+	return g, nil
+}
+
+func (r *friendGroupRepository) DeleteGroup(ctx context.Context, uId, gId string) error {
+	stmt, names := qb.
+		Delete(FRIEND_GROUPS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("group_id")).
+		Existing().
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":  uId,
+			"group_id": gId,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return errors.New("group not found")
+	}
+
+	stmt, names = qb.
+		Delete(FRIEND_GROUP_MEMBERS).
+		Where(qb.Eq("group_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"group_id": gId})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *friendGroupRepository) GetGroup(ctx context.Context, uId, gId string) (res datastruct.FriendGroup, err error) {
+	stmt, names := qb.
+		Select(FRIEND_GROUPS).
+		Columns(friendGroupMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("group_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":  uId,
+			"group_id": gId,
+		})).
+		GetRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r *friendGroupRepository) GetGroups(ctx context.Context, uId string, page []byte, limit uint64) (res []datastruct.FriendGroup, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FRIEND_GROUPS).
+		Columns(friendGroupMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"user_id": uId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FriendGroup{}, nil, errors.New("no groups found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r *friendGroupRepository) AddGroupMember(ctx context.Context, gId, mId string) (datastruct.FriendGroupMember, error) {
+	m := datastruct.FriendGroupMember{
+		GroupId: gId,
+		UserId:  mId,
+		AddedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, m)
+	if err != nil {
+		return datastruct.FriendGroupMember{}, err
+	}
+
+	stmt, names := qb.
+		Insert(FRIEND_GROUP_MEMBERS).
+		Columns(friendGroupMemberMetadata.Columns...).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(m).
+		ExecRelease()
+	if err != nil {
+		return datastruct.FriendGroupMember{}, err
+	}
+
+	return m, nil
+}
+
+func (r *friendGroupRepository) RemoveGroupMember(ctx context.Context, gId, mId string) error {
+	stmt, names := qb.
+		Delete(FRIEND_GROUP_MEMBERS).
+		Where(qb.Eq("group_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"group_id": gId,
+			"user_id":  mId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *friendGroupRepository) GetGroupMembers(ctx context.Context, gId string, page []byte, limit uint64) (res []datastruct.FriendGroupMember, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FRIEND_GROUP_MEMBERS).
+		Columns(friendGroupMemberMetadata.Columns...).
+		Where(qb.Eq("group_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"group_id": gId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FriendGroupMember{}, nil, errors.New("no group members found")
+	}
+
+	return res, iter.PageState(), nil
+}
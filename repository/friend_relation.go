@@ -3,19 +3,27 @@ package repository
 import (
 	"context"
 	"errors"
-	"sync"
+	"strings"
 	"time"
 
 	"github.com/clubo-app/relation-service/datastruct"
 	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
 	"github.com/scylladb/gocqlx/v2"
 	"github.com/scylladb/gocqlx/v2/qb"
 	"github.com/scylladb/gocqlx/v2/table"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	FRIEND_RELATIONS string = "friend_relations"
-	FRIEND_COUNT     string = "friend_count"
+	FRIEND_RELATIONS         string = "friend_relations"
+	FRIEND_COUNT             string = "friend_count"
+	FRIEND_REQUEST_EXPIRIES  string = "friend_request_expiries"
+	FRIENDS_BY_ACCEPTED_AT   string = "friends_by_accepted_at"
+	FRIENDS_BY_NAME          string = "friends_by_name"
+	USER_DISPLAY_NAMES       string = "user_display_names"
+	FRIEND_TOKEN_REDEMPTIONS string = "friend_token_redemptions"
 )
 
 var friendCountMetadata = table.Metadata{
@@ -25,35 +33,70 @@ var friendCountMetadata = table.Metadata{
 }
 var friendRelationMetadata = table.Metadata{
 	Name:    FRIEND_RELATIONS,
-	Columns: []string{"user_id", "friend_id", "accepted", "requested_at", "accepted_at"},
+	Columns: []string{"user_id", "friend_id", "accepted", "requested_at", "accepted_at", "expires_at", "message", "source_party_id"},
 	PartKey: []string{"user_id", "friend_id"},
 }
+var friendRequestExpiryMetadata = table.Metadata{
+	Name:    FRIEND_REQUEST_EXPIRIES,
+	Columns: []string{"expires_on", "expires_at", "user_id", "friend_id"},
+	PartKey: []string{"expires_on"},
+	SortKey: []string{"expires_at", "user_id", "friend_id"},
+}
+var friendByNameMetadata = table.Metadata{
+	Name:    FRIENDS_BY_NAME,
+	Columns: []string{"user_id", "display_name", "friend_id", "accepted_at"},
+	PartKey: []string{"user_id"},
+	SortKey: []string{"display_name", "friend_id"},
+}
+var friendTokenRedemptionMetadata = table.Metadata{
+	Name:    FRIEND_TOKEN_REDEMPTIONS,
+	Columns: []string{"user_id", "redeemed_at", "friend_id"},
+	PartKey: []string{"user_id"},
+	SortKey: []string{"redeemed_at"},
+}
+var userDisplayNameMetadata = table.Metadata{
+	Name:    USER_DISPLAY_NAMES,
+	Columns: []string{"user_id", "display_name"},
+	PartKey: []string{"user_id"},
+}
 
 type FriendRelationRepository interface {
-	CreateFriendRequest(ctx context.Context, uId string, fId string) error
+	CreateFriendRequest(ctx context.Context, uId, fId, message, sourcePartyId string) error
 	DeclineFriendRequest(ctx context.Context, uId, fId string) error
 	AcceptFriendRequest(ctx context.Context, uId, fId string) error
 	RemoveFriendRelation(ctx context.Context, uId, fId string) error
 	GetFriendRelation(ctx context.Context, uId, fId string) (datastruct.FriendRelation, error)
 	GetFriends(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendRelation, []byte, error)
+	GetFriendsByAcceptedAt(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendRelation, []byte, error)
+	GetFriendsByDisplayName(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendRelation, []byte, error)
+	UpdateDisplayName(ctx context.Context, uId, displayName string) error
 	GetIncomingFriendRequests(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendRelation, []byte, error)
 	IncreaseFriendCount(ctx context.Context, uId string) error
 	DecreaseFriendCount(ctx context.Context, uId string) error
 	GetFriendCount(ctx context.Context, uId string) (datastruct.FriendCount, error)
 	GetManyFriendCount(ctx context.Context, ids []string) ([]datastruct.FriendCount, error)
+	GetExpiredFriendRequests(ctx context.Context, day time.Time, until time.Time) ([]datastruct.FriendRequestExpiry, error)
+	ExpireFriendRequest(ctx context.Context, e datastruct.FriendRequestExpiry) (bool, error)
+	AddTokenRedemption(ctx context.Context, uId, fId string) error
+	CountTokenRedemptions(ctx context.Context, uId string, since time.Time) (int, error)
 }
 
 type friendRelationRepository struct {
-	sess *gocqlx.Session
-	val  *validator.Validate
+	sess       *gocqlx.Session
+	val        *validator.Validate
+	requestTTL time.Duration
 }
 
-func (r *friendRelationRepository) CreateFriendRequest(ctx context.Context, uId string, fId string) error {
+func (r *friendRelationRepository) CreateFriendRequest(ctx context.Context, uId, fId, message, sourcePartyId string) error {
+	now := time.Now()
 	fr := datastruct.FriendRelation{
-		FriendId:    uId,
-		UserId:      fId,
-		Accepted:    false,
-		RequestedAt: time.Now(),
+		FriendId:      uId,
+		UserId:        fId,
+		Accepted:      false,
+		RequestedAt:   now,
+		ExpiresAt:     now.Add(r.requestTTL),
+		Message:       message,
+		SourcePartyId: sourcePartyId,
 	}
 
 	err := r.val.StructCtx(ctx, fr)
@@ -75,6 +118,28 @@ func (r *friendRelationRepository) CreateFriendRequest(ctx context.Context, uId
 		return err
 	}
 
+	// The pending request is removed by the sweeper once it expired, so it needs to be able to find it by date
+	e := datastruct.FriendRequestExpiry{
+		ExpiresOn: expiryDay(fr.ExpiresAt),
+		ExpiresAt: fr.ExpiresAt,
+		UserId:    fr.UserId,
+		FriendId:  fr.FriendId,
+	}
+
+	stmt, names = qb.
+		Insert(FRIEND_REQUEST_EXPIRIES).
+		Columns(friendRequestExpiryMetadata.Columns...).
+		TTL(r.requestTTL + expiryLookback).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(e).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -100,74 +165,120 @@ func (r *friendRelationRepository) DeclineFriendRequest(ctx context.Context, uId
 
 // This Method accepts a friend request and adds both users to each others friend list
 func (r *friendRelationRepository) AcceptFriendRequest(ctx context.Context, uId, fId string) error {
-	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	now := time.Now()
 
-	var err error
+	stmt, names := qb.
+		Update(FRIEND_RELATIONS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("friend_id")).
+		If(qb.EqNamed("accepted", "old.accepted")).
+		Set("accepted").
+		Set("accepted_at").
+		Set("expires_at").
+		ToCql()
 
-	go func() {
-		defer wg.Done()
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":      uId,
+			"friend_id":    fId,
+			"old.accepted": false,
+			"accepted":     true,
+			"accepted_at":  now,
+			"expires_at":   nil,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return err
+	}
+	// Nothing is added for requests which don't exist or were already accepted
+	if !applied {
+		return status.Error(codes.NotFound, "Friend request not found")
+	}
 
-		stmt, names := qb.
-			Update(FRIEND_RELATIONS).
-			Where(qb.Eq("user_id")).
-			Where(qb.Eq("friend_id")).
-			If(qb.EqNamed("accepted", "old.accepted")).
-			Set("accepted").
-			Set("accepted_at").
-			ToCql()
+	stmt, names = qb.
+		Insert(FRIEND_RELATIONS).
+		Unique().
+		Columns(friendRelationMetadata.Columns...).
+		ToCql()
 
-		err1 := r.sess.
-			ContextQuery(ctx, stmt, names).
-			BindMap((qb.M{
-				"user_id":      uId,
-				"friend_id":    fId,
-				"old.accepted": false,
-				"accepted":     true,
-				"accepted_at":  time.Now(),
-			})).
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":         fId,
+			"friend_id":       uId,
+			"accepted":        true,
+			"accepted_at":     now,
+			"requested_at":    now,
+			"expires_at":      nil,
+			"message":         nil,
+			"source_party_id": nil,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return r.addFriendsByName(ctx, uId, fId, now)
+}
+
+// The friendship itself is removed last, so a failed removal can be retried until everything derived from it is gone
+func (r *friendRelationRepository) RemoveFriendRelation(ctx context.Context, uId, fId string) error {
+	// Users who aren't friends anymore can't be close friends either
+	stmt, names := qb.
+		Delete(CLOSE_FRIENDS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("friend_id")).
+		ToCql()
+
+	for _, m := range []qb.M{
+		{"user_id": uId, "friend_id": fId},
+		{"user_id": fId, "friend_id": uId},
+	} {
+		err := r.sess.ContextQuery(ctx, stmt, names).
+			BindMap(m).
 			ExecRelease()
-		if err1 != nil {
-			err = err1
+		if err != nil {
+			return err
 		}
-	}()
+	}
 
-	go func() {
-		defer wg.Done()
+	uName, err := r.getDisplayName(ctx, uId)
+	if err != nil {
+		return err
+	}
+	fName, err := r.getDisplayName(ctx, fId)
+	if err != nil {
+		return err
+	}
 
-		stmt, names := qb.
-			Insert(FRIEND_RELATIONS).
-			Unique().
-			Columns(friendRelationMetadata.Columns...).
-			ToCql()
+	// Both users drop out of each others name ordered friend list
+	stmt, names = qb.
+		Delete(FRIENDS_BY_NAME).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("display_name")).
+		Where(qb.Eq("friend_id")).
+		ToCql()
 
-		err2 := r.sess.
-			ContextQuery(ctx, stmt, names).
-			BindMap((qb.M{
-				"user_id":      fId,
-				"friend_id":    uId,
-				"accepted":     true,
-				"accepted_at":  time.Now(),
-				"requested_at": time.Now(),
-			})).
+	for _, m := range []qb.M{
+		{"user_id": uId, "display_name": fName, "friend_id": fId},
+		{"user_id": fId, "display_name": uName, "friend_id": uId},
+	} {
+		err = r.sess.ContextQuery(ctx, stmt, names).
+			BindMap(m).
 			ExecRelease()
-		if err2 != nil {
-			err = err2
+		if err != nil {
+			return err
 		}
-	}()
-
-	wg.Wait()
-	return err
-}
+	}
 
-func (r *friendRelationRepository) RemoveFriendRelation(ctx context.Context, uId, fId string) error {
-	stmt, names := qb.
+	stmt, names = qb.
 		Delete(FRIEND_RELATIONS).
 		Where(qb.Eq("user_id")).
 		Where(qb.Eq("friend_id")).
 		ToCql()
 
-	err := r.sess.ContextQuery(ctx, stmt, names).
+	err = r.sess.ContextQuery(ctx, stmt, names).
 		BindMap((qb.M{
 			"user_id":   uId,
 			"friend_id": fId,
@@ -180,6 +291,7 @@ func (r *friendRelationRepository) RemoveFriendRelation(ctx context.Context, uId
 	return nil
 }
 
+// Looks up the relation in both directions, returns NotFound if there is none
 func (r *friendRelationRepository) GetFriendRelation(ctx context.Context, uId, fId string) (res datastruct.FriendRelation, err error) {
 	stmt, names := qb.
 		Select(FRIEND_RELATIONS).
@@ -195,24 +307,20 @@ func (r *friendRelationRepository) GetFriendRelation(ctx context.Context, uId, f
 			"friend_id": fId,
 		})).
 		GetRelease(&res)
-	if err != nil {
-		stmt2, names2 := qb.
-			Select(FRIEND_RELATIONS).
-			Columns(friendRelationMetadata.Columns...).
-			Where(qb.Eq("user_id")).
-			Where(qb.Eq("friend_id")).
-			ToCql()
-
+	if err == gocql.ErrNotFound {
 		err = r.sess.
-			ContextQuery(ctx, stmt2, names2).
+			ContextQuery(ctx, stmt, names).
 			BindMap((qb.M{
 				"user_id":   fId,
 				"friend_id": uId,
 			})).
 			GetRelease(&res)
-		if err != nil {
-			return res, err
-		}
+	}
+	if err == gocql.ErrNotFound {
+		return res, status.Error(codes.NotFound, "Friend relation not found")
+	}
+	if err != nil {
+		return res, err
 	}
 
 	return res, nil
@@ -249,6 +357,66 @@ func (r *friendRelationRepository) GetFriends(ctx context.Context, uId string, p
 	return res, iter.PageState(), nil
 }
 
+func (r *friendRelationRepository) GetFriendsByAcceptedAt(ctx context.Context, uId string, page []byte, limit uint64) (res []datastruct.FriendRelation, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FRIENDS_BY_ACCEPTED_AT).
+		Columns(friendRelationMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"user_id": uId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FriendRelation{}, nil, errors.New("no friends found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r *friendRelationRepository) GetFriendsByDisplayName(ctx context.Context, uId string, page []byte, limit uint64) (res []datastruct.FriendRelation, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(FRIENDS_BY_NAME).
+		Columns("user_id", "friend_id", "accepted_at").
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"user_id": uId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.FriendRelation{}, nil, errors.New("no friends found")
+	}
+
+	for i := range res {
+		res[i].Accepted = true
+	}
+
+	return res, iter.PageState(), nil
+}
+
 func (r *friendRelationRepository) GetIncomingFriendRequests(ctx context.Context, uId string, page []byte, limit uint64) (res []datastruct.FriendRelation, nextPage []byte, err error) {
 	stmt, names := qb.
 		Select(FRIEND_RELATIONS).
@@ -277,7 +445,16 @@ func (r *friendRelationRepository) GetIncomingFriendRequests(ctx context.Context
 		return []datastruct.FriendRelation{}, nil, errors.New("no friend requests found")
 	}
 
-	return res, iter.PageState(), nil
+	// Requests which expired but weren't swept yet should not show up anymore
+	now := time.Now()
+	pending := res[:0]
+	for _, fr := range res {
+		if fr.ExpiresAt.IsZero() || fr.ExpiresAt.After(now) {
+			pending = append(pending, fr)
+		}
+	}
+
+	return pending, iter.PageState(), nil
 }
 
 func (r *friendRelationRepository) IncreaseFriendCount(ctx context.Context, uId string) error {
@@ -352,3 +529,327 @@ func (r *friendRelationRepository) GetManyFriendCount(ctx context.Context, ids [
 
 	return res, nil
 }
+
+// Expiry entries are kept around a bit longer than the request so the sweeper still finds them after downtime
+const expiryLookback = 7 * 24 * time.Hour
+
+func expiryDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func (r *friendRelationRepository) GetExpiredFriendRequests(ctx context.Context, day time.Time, until time.Time) (res []datastruct.FriendRequestExpiry, err error) {
+	stmt, names := qb.
+		Select(FRIEND_REQUEST_EXPIRIES).
+		Columns(friendRequestExpiryMetadata.Columns...).
+		Where(qb.Eq("expires_on")).
+		Where(qb.LtOrEq("expires_at")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"expires_on": expiryDay(day),
+			"expires_at": until,
+		})).
+		SelectRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Removes the friend request if it is still pending. Returns false if the request was already accepted or removed.
+func (r *friendRelationRepository) ExpireFriendRequest(ctx context.Context, e datastruct.FriendRequestExpiry) (bool, error) {
+	stmt, names := qb.
+		Delete(FRIEND_RELATIONS).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("friend_id")).
+		If(qb.EqNamed("accepted", "old.accepted")).
+		If(qb.EqNamed("expires_at", "old.expires_at")).
+		ToCql()
+
+	// Matching expires_at keeps a leftover expiry of an earlier request from deleting a newer one
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":        e.UserId,
+			"friend_id":      e.FriendId,
+			"old.accepted":   false,
+			"old.expires_at": e.ExpiresAt,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return false, err
+	}
+
+	stmt, names = qb.
+		Delete(FRIEND_REQUEST_EXPIRIES).
+		Where(qb.Eq("expires_on")).
+		Where(qb.Eq("expires_at")).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("friend_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(e).
+		ExecRelease()
+	if err != nil {
+		return applied, err
+	}
+
+	return applied, nil
+}
+
+func (r *friendRelationRepository) getDisplayName(ctx context.Context, uId string) (string, error) {
+	stmt, names := qb.
+		Select(USER_DISPLAY_NAMES).
+		Columns(userDisplayNameMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	var res struct {
+		UserId      string `db:"user_id"`
+		DisplayName string `db:"display_name"`
+	}
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"user_id": uId})).
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return res.DisplayName, nil
+}
+
+// Adds both users to each others friend list ordered by display name
+func (r *friendRelationRepository) addFriendsByName(ctx context.Context, uId, fId string, acceptedAt time.Time) error {
+	uName, err := r.getDisplayName(ctx, uId)
+	if err != nil {
+		return err
+	}
+	fName, err := r.getDisplayName(ctx, fId)
+	if err != nil {
+		return err
+	}
+
+	stmt, names := qb.
+		Insert(FRIENDS_BY_NAME).
+		Columns(friendByNameMetadata.Columns...).
+		ToCql()
+
+	for _, m := range []qb.M{
+		{"user_id": uId, "display_name": fName, "friend_id": fId, "accepted_at": acceptedAt},
+		{"user_id": fId, "display_name": uName, "friend_id": uId, "accepted_at": acceptedAt},
+	} {
+		err = r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindMap(m).
+			ExecRelease()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Caches the display name of a user and moves the user to the right position in the name ordered friend lists of all friends
+func (r *friendRelationRepository) UpdateDisplayName(ctx context.Context, uId, displayName string) error {
+	newName := strings.ToLower(displayName)
+
+	oldName, err := r.getDisplayName(ctx, uId)
+	if err != nil {
+		return err
+	}
+	if oldName == newName {
+		return nil
+	}
+
+	stmt, names := qb.
+		Insert(USER_DISPLAY_NAMES).
+		Columns(userDisplayNameMetadata.Columns...).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":      uId,
+			"display_name": newName,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	stmt, names = qb.
+		Select(FRIEND_RELATIONS).
+		Columns(friendRelationMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("accepted")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":  uId,
+			"accepted": true,
+		}))
+	defer q.Release()
+
+	batchStmt, batchNames := qb.Batch().
+		AddWithPrefix("old", qb.
+			Delete(FRIENDS_BY_NAME).
+			Where(qb.Eq("user_id")).
+			Where(qb.Eq("display_name")).
+			Where(qb.Eq("friend_id"))).
+		AddWithPrefix("new", qb.
+			Insert(FRIENDS_BY_NAME).
+			Columns(friendByNameMetadata.Columns...)).
+		ToCql()
+
+	iter := q.Iter()
+	var fr datastruct.FriendRelation
+	for iter.StructScan(&fr) {
+		err = r.sess.
+			ContextQuery(ctx, batchStmt, batchNames).
+			BindMap((qb.M{
+				"old.user_id":      fr.FriendId,
+				"old.display_name": oldName,
+				"old.friend_id":    uId,
+				"new.user_id":      fr.FriendId,
+				"new.display_name": newName,
+				"new.friend_id":    uId,
+				"new.accepted_at":  fr.AcceptedAt,
+			})).
+			ExecRelease()
+		if err != nil {
+			iter.Close()
+			return err
+		}
+	}
+
+	return iter.Close()
+}
+
+const (
+	backfillPageSize = 100
+	backfillPause    = 100 * time.Millisecond
+)
+
+// Adds all friendships accepted before friends_by_name existed to it.
+// Runs once as part of the migrations, the inserts are idempotent so it can be rerun after a failure.
+func BackfillFriendsByName(ctx context.Context, sess *gocqlx.Session) error {
+	r := &friendRelationRepository{sess: sess}
+
+	stmt, names := qb.
+		Select(FRIEND_RELATIONS).
+		Columns(friendRelationMetadata.Columns...).
+		ToCql()
+
+	insertStmt, insertNames := qb.
+		Insert(FRIENDS_BY_NAME).
+		Columns(friendByNameMetadata.Columns...).
+		ToCql()
+
+	var p []byte
+	for {
+		q := sess.
+			ContextQuery(ctx, stmt, names).
+			PageState(p).
+			PageSize(backfillPageSize)
+
+		var frs []datastruct.FriendRelation
+		iter := q.Iter()
+		err := iter.Select(&frs)
+		next := iter.PageState()
+		q.Release()
+		if err != nil {
+			return err
+		}
+
+		for _, fr := range frs {
+			if !fr.Accepted {
+				continue
+			}
+
+			name, err := r.getDisplayName(ctx, fr.FriendId)
+			if err != nil {
+				return err
+			}
+
+			err = sess.
+				ContextQuery(ctx, insertStmt, insertNames).
+				BindMap((qb.M{
+					"user_id":      fr.UserId,
+					"display_name": name,
+					"friend_id":    fr.FriendId,
+					"accepted_at":  fr.AcceptedAt,
+				})).
+				ExecRelease()
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(next) == 0 {
+			return nil
+		}
+		p = next
+
+		time.Sleep(backfillPause)
+	}
+}
+
+// Redemptions are only kept as long as they are needed for rate limiting
+const tokenRedemptionTTL = 24 * time.Hour
+
+func (r *friendRelationRepository) AddTokenRedemption(ctx context.Context, uId, fId string) error {
+	stmt, names := qb.
+		Insert(FRIEND_TOKEN_REDEMPTIONS).
+		Columns(friendTokenRedemptionMetadata.Columns...).
+		TTL(tokenRedemptionTTL).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":     uId,
+			"redeemed_at": time.Now(),
+			"friend_id":   fId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *friendRelationRepository) CountTokenRedemptions(ctx context.Context, uId string, since time.Time) (int, error) {
+	stmt, names := qb.
+		Select(FRIEND_TOKEN_REDEMPTIONS).
+		CountAll().
+		Where(qb.Eq("user_id")).
+		Where(qb.GtNamed("redeemed_at", "since")).
+		ToCql()
+
+	var count int
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id": uId,
+			"since":   since,
+		})).
+		GetRelease(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -7,7 +7,9 @@ import (
 	"strings"
 
 	"github.com/clubo-app/packages/cqlx"
+	"github.com/clubo-app/relation-service/repository"
 	"github.com/clubo-app/relation-service/repository/migrations/cql"
+	"github.com/scylladb/gocqlx/v2"
 	"github.com/scylladb/gocqlx/v2/migrate"
 )
 
@@ -36,6 +38,13 @@ func main() {
 	}
 	defer session.Close()
 
+	// Backfills which can't be written in CQL run as part of the migration that calls them
+	callbacks := migrate.CallbackRegister{}
+	callbacks.Add(migrate.CallComment, "backfill_friends_by_name", func(ctx context.Context, s gocqlx.Session, ev migrate.CallbackEvent, name string) error {
+		return repository.BackfillFriendsByName(ctx, &s)
+	})
+	migrate.Callback = callbacks.Callback
+
 	if err := migrate.FromFS(ctx, session, cql.Files); err != nil {
 		log.Fatal("Migrate: ", err)
 	}
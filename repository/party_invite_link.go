@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PARTY_INVITE_LINKS          string = "party_invite_links"
+	PARTY_INVITE_LINKS_BY_PARTY string = "party_invite_links_by_party"
+)
+
+var partyInviteLinkMetadata = table.Metadata{
+	Name:    PARTY_INVITE_LINKS,
+	Columns: []string{"link_id", "party_id", "creator_id", "max_uses", "uses", "expires_at", "created_at", "revoked"},
+	PartKey: []string{"link_id"},
+}
+
+// How often a use is retried when other redemptions of the same link interfere
+const maxLinkUseAttempts = 5
+
+type PartyInviteLinkRepository interface {
+	CreateInviteLink(context.Context, CreateInviteLinkParams) (datastruct.PartyInviteLink, error)
+	GetInviteLink(ctx context.Context, lId string) (datastruct.PartyInviteLink, error)
+	UseInviteLink(ctx context.Context, lId string) (datastruct.PartyInviteLink, error)
+	RevokeInviteLink(ctx context.Context, lId string) error
+	GetPartyInviteLinks(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.PartyInviteLink, []byte, error)
+	DeleteInviteLinks(ctx context.Context, lIds []string) error
+}
+
+type partyInviteLinkRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+type CreateInviteLinkParams struct {
+	PartyId   string
+	CreatorId string
+	MaxUses   int
+	ExpiresAt time.Time
+}
+
+func (r *partyInviteLinkRepository) CreateInviteLink(ctx context.Context, params CreateInviteLinkParams) (datastruct.PartyInviteLink, error) {
+	l := datastruct.PartyInviteLink{
+		LinkId:    ksuid.New().String(),
+		PartyId:   params.PartyId,
+		CreatorId: params.CreatorId,
+		MaxUses:   params.MaxUses,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, l)
+	if err != nil {
+		return datastruct.PartyInviteLink{}, err
+	}
+
+	b := qb.
+		Insert(PARTY_INVITE_LINKS).
+		Columns(partyInviteLinkMetadata.Columns...)
+	if !l.ExpiresAt.IsZero() {
+		b.TTL(time.Until(l.ExpiresAt) + expiryLookback)
+	}
+	stmt, names := b.ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(l).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartyInviteLink{}, err
+	}
+
+	return l, nil
+}
+
+func (r *partyInviteLinkRepository) GetInviteLink(ctx context.Context, lId string) (res datastruct.PartyInviteLink, err error) {
+	stmt, names := qb.
+		Select(PARTY_INVITE_LINKS).
+		Columns(partyInviteLinkMetadata.Columns...).
+		Where(qb.Eq("link_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"link_id": lId})).
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return res, status.Error(codes.NotFound, "Invite link not found")
+	}
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Counts a use of the link if it is still usable
+func (r *partyInviteLinkRepository) UseInviteLink(ctx context.Context, lId string) (datastruct.PartyInviteLink, error) {
+	for i := 0; i < maxLinkUseAttempts; i++ {
+		l, err := r.GetInviteLink(ctx, lId)
+		if err != nil {
+			return datastruct.PartyInviteLink{}, err
+		}
+		if l.Revoked {
+			return datastruct.PartyInviteLink{}, status.Error(codes.FailedPrecondition, "Invite link revoked")
+		}
+		if !l.ExpiresAt.IsZero() && l.ExpiresAt.Before(time.Now()) {
+			return datastruct.PartyInviteLink{}, status.Error(codes.FailedPrecondition, "Invite link expired")
+		}
+		if l.MaxUses > 0 && l.Uses >= l.MaxUses {
+			return datastruct.PartyInviteLink{}, status.Error(codes.ResourceExhausted, "Invite link used up")
+		}
+
+		stmt, names := qb.
+			Update(PARTY_INVITE_LINKS).
+			Set("uses").
+			Where(qb.Eq("link_id")).
+			If(qb.EqNamed("uses", "old.uses")).
+			If(qb.EqNamed("revoked", "old.revoked")).
+			ToCql()
+
+		applied, err := r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindMap((qb.M{
+				"uses":        l.Uses + 1,
+				"link_id":     lId,
+				"old.uses":    l.Uses,
+				"old.revoked": false,
+			})).
+			ExecCASRelease()
+		if err != nil {
+			return datastruct.PartyInviteLink{}, err
+		}
+		if applied {
+			l.Uses++
+			return l, nil
+		}
+	}
+
+	return datastruct.PartyInviteLink{}, status.Error(codes.Aborted, "Invite link is busy, try again")
+}
+
+func (r *partyInviteLinkRepository) RevokeInviteLink(ctx context.Context, lId string) error {
+	stmt, names := qb.
+		Update(PARTY_INVITE_LINKS).
+		Set("revoked").
+		Where(qb.Eq("link_id")).
+		Existing().
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"revoked": true,
+			"link_id": lId,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return status.Error(codes.NotFound, "Invite link not found")
+	}
+
+	return nil
+}
+
+func (r *partyInviteLinkRepository) GetPartyInviteLinks(ctx context.Context, pId string, page []byte, limit uint64) (res []datastruct.PartyInviteLink, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_INVITE_LINKS_BY_PARTY).
+		Columns(partyInviteLinkMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.PartyInviteLink{}, nil, errors.New("no invite links found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+// Deleted links can't be redeemed anymore, every link is its own partition so the deletes are batched
+func (r *partyInviteLinkRepository) DeleteInviteLinks(ctx context.Context, lIds []string) error {
+	if len(lIds) == 0 {
+		return nil
+	}
+
+	b := qb.Batch()
+	m := qb.M{}
+	for n, lId := range lIds {
+		lp := fmt.Sprintf("link%d", n)
+		b.AddWithPrefix(lp, qb.
+			Delete(PARTY_INVITE_LINKS).
+			Where(qb.Eq("link_id")))
+		m[lp+".link_id"] = lId
+	}
+
+	stmt, names := b.ToCql()
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap(m).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,11 +2,12 @@ package repository
 
 import (
 	"context"
-	"sync"
+	"fmt"
 	"time"
 
 	"github.com/clubo-app/relation-service/datastruct"
 	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
 	"github.com/scylladb/gocqlx/v2"
 	"github.com/scylladb/gocqlx/v2/qb"
 	"github.com/scylladb/gocqlx/v2/table"
@@ -18,28 +19,113 @@ const (
 	PARTY_PARTICIPANTS         string = "party_participants"
 	PARTY_PARTICIPANTS_BY_USER string = "party_participants_by_user"
 	PARTY_INVITES              string = "party_invites"
+	PARTY_INVITES_BY_PARTY     string = "party_invites_by_party"
+	PARTY_INVITE_EXPIRIES      string = "party_invite_expiries"
+	PARTY_PARTICIPANT_COUNT    string = "party_participant_count"
+	PARTY_WAITLIST             string = "party_waitlist"
+	PARTY_WAITLIST_BY_QUEUED   string = "party_waitlist_by_queued_at"
+	PARTY_JOIN_REQUESTS        string = "party_join_requests"
+	PARTY_BANS                 string = "party_bans"
+	PARTY_CHECK_INS            string = "party_check_ins"
 )
 
 var partyParticipantMetadata = table.Metadata{
 	Name:    PARTY_PARTICIPANTS,
-	Columns: []string{"user_id", "party_id", "joined_at"},
+	Columns: []string{"user_id", "party_id", "joined_at", "plus_ones", "guest_names", "checked_in_at", "checked_in_by"},
 	PartKey: []string{"party_id", "user_id"},
 }
 
+var partyParticipantCountMetadata = table.Metadata{
+	Name:    PARTY_PARTICIPANT_COUNT,
+	Columns: []string{"party_id", "participant_count", "guest_count", "checked_in_count"},
+	PartKey: []string{"party_id"},
+}
+
+var partyWaitlistMetadata = table.Metadata{
+	Name:    PARTY_WAITLIST,
+	Columns: []string{"party_id", "user_id", "queued_at"},
+	PartKey: []string{"party_id"},
+	SortKey: []string{"user_id"},
+}
+
+var partyJoinRequestMetadata = table.Metadata{
+	Name:    PARTY_JOIN_REQUESTS,
+	Columns: []string{"party_id", "user_id", "requested_at"},
+	PartKey: []string{"party_id"},
+	SortKey: []string{"user_id"},
+}
+
+var partyCheckInMetadata = table.Metadata{
+	Name:    PARTY_CHECK_INS,
+	Columns: []string{"party_id", "user_id", "checked_in_at", "checked_in_by"},
+	PartKey: []string{"party_id"},
+	SortKey: []string{"user_id"},
+}
+
+var partyBanMetadata = table.Metadata{
+	Name:    PARTY_BANS,
+	Columns: []string{"party_id", "user_id", "banned_by", "banned_at"},
+	PartKey: []string{"party_id"},
+	SortKey: []string{"user_id"},
+}
+
 var partyInviteMetadata = table.Metadata{
 	Name:    PARTY_INVITES,
-	Columns: []string{"user_id", "party_id", "inviter_id"},
+	Columns: []string{"user_id", "party_id", "inviter_id", "valid_until"},
 	PartKey: []string{"user_id", "party_id"},
 }
 
+var partyInviteExpiryMetadata = table.Metadata{
+	Name:    PARTY_INVITE_EXPIRIES,
+	Columns: []string{"expires_on", "valid_until", "user_id", "party_id", "inviter_id"},
+	PartKey: []string{"expires_on"},
+	SortKey: []string{"valid_until", "user_id", "party_id"},
+}
+
 type PartyParticipantsRepository interface {
 	Invite(context.Context, InviteParams) (datastruct.PartyInvite, error)
+	BulkInvite(context.Context, BulkInviteParams) ([]datastruct.PartyInvite, error)
+	GetInvite(context.Context, UserPartyParams) (datastruct.PartyInvite, error)
 	Decline(context.Context, UserPartyParams) error
 	Accept(context.Context, UserPartyParams) error
 	GetUserInvites(context.Context, GetUserInvitesParams) ([]datastruct.PartyInvite, []byte, error)
 	Join(context.Context, UserPartyParams) error
-	Leave(context.Context, UserPartyParams) error
+	Leave(context.Context, UserPartyParams) (datastruct.PartyParticipant, error)
 	GetPartyParticipants(context.Context, GetPartyParticipantsParams) ([]datastruct.PartyParticipant, []byte, error)
+	GetParticipant(context.Context, UserPartyParams) (datastruct.PartyParticipant, error)
+	IsParticipant(context.Context, UserPartyParams) (bool, error)
+	GetParticipantsIn(ctx context.Context, pId string, uIds []string) ([]datastruct.PartyParticipant, error)
+	SetPlusOnes(context.Context, SetPlusOnesParams) (datastruct.PartyParticipant, error)
+	CheckIn(context.Context, CheckInParams) (datastruct.PartyCheckIn, error)
+	GetCheckedIn(context.Context, GetCheckedInParams) ([]datastruct.PartyCheckIn, []byte, error)
+	GetUserParticipations(context.Context, GetUserParticipationsParams) ([]datastruct.PartyParticipant, []byte, error)
+	GetExpiredInvites(ctx context.Context, day time.Time, until time.Time) ([]datastruct.PartyInviteExpiry, error)
+	ExpireInvite(context.Context, datastruct.PartyInviteExpiry) (bool, error)
+	IncreaseParticipantCount(ctx context.Context, pId string) error
+	DecreaseParticipantCount(ctx context.Context, pId string) error
+	ChangeGuestCount(ctx context.Context, pId string, delta int) error
+	IncreaseCheckedInCount(ctx context.Context, pId string) error
+	DecreaseCheckedInCount(ctx context.Context, pId string) error
+	GetParticipantCount(ctx context.Context, pId string) (datastruct.PartyParticipantCount, error)
+	GetManyParticipantCount(ctx context.Context, pIds []string) ([]datastruct.PartyParticipantCount, error)
+	JoinWaitlist(context.Context, UserPartyParams) (datastruct.WaitlistEntry, error)
+	LeaveWaitlist(context.Context, UserPartyParams) error
+	GetWaitlist(context.Context, GetWaitlistParams) ([]datastruct.WaitlistEntry, []byte, error)
+	PopWaitlist(ctx context.Context, pId string) (datastruct.WaitlistEntry, error)
+	RequestToJoin(context.Context, UserPartyParams) (datastruct.PartyJoinRequest, error)
+	GetJoinRequests(context.Context, GetJoinRequestsParams) ([]datastruct.PartyJoinRequest, []byte, error)
+	ApproveJoinRequest(context.Context, UserPartyParams) error
+	RejectJoinRequest(context.Context, UserPartyParams) error
+	Ban(context.Context, BanParams) (datastruct.PartyBan, error)
+	Unban(context.Context, UserPartyParams) error
+	IsBanned(context.Context, UserPartyParams) (bool, error)
+	GetBans(context.Context, GetBansParams) ([]datastruct.PartyBan, []byte, error)
+	GetPartyInvites(context.Context, GetPartyInvitesParams) ([]datastruct.PartyInvite, []byte, error)
+	RemoveInvites(ctx context.Context, is []datastruct.PartyInvite) error
+	RemoveParticipants(ctx context.Context, pId string, uIds []string) error
+	RemoveWaitlistEntries(ctx context.Context, pId string, uIds []string) error
+	DeletePartyData(ctx context.Context, pId string) error
+	DeleteParticipantCount(ctx context.Context, pId string) error
 }
 
 type partyParticipantRepository struct {
@@ -54,6 +140,7 @@ type InviteParams struct {
 	ValidFor  time.Duration
 }
 
+// An existing invite of the user is overwritten, which renews it like BulkInvite does
 func (r partyParticipantRepository) Invite(ctx context.Context, params InviteParams) (datastruct.PartyInvite, error) {
 	i := datastruct.PartyInvite{
 		UserId:     params.UserId,
@@ -68,9 +155,8 @@ func (r partyParticipantRepository) Invite(ctx context.Context, params InvitePar
 
 	stmt, names := qb.
 		Insert(PARTY_INVITES).
-		Unique().
 		Columns(partyInviteMetadata.Columns...).
-		TTL(params.ValidFor).
+		TTL(params.ValidFor + expiryLookback).
 		ToCql()
 
 	err = r.sess.
@@ -81,14 +167,137 @@ func (r partyParticipantRepository) Invite(ctx context.Context, params InvitePar
 		return datastruct.PartyInvite{}, err
 	}
 
+	e := datastruct.PartyInviteExpiry{
+		ExpiresOn:  expiryDay(i.ValidUntil),
+		ValidUntil: i.ValidUntil,
+		UserId:     i.UserId,
+		PartyId:    i.PartyId,
+		InviterId:  i.InviterId,
+	}
+
+	stmt, names = qb.
+		Insert(PARTY_INVITE_EXPIRIES).
+		Columns(partyInviteExpiryMetadata.Columns...).
+		TTL(params.ValidFor + expiryLookback).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(e).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartyInvite{}, err
+	}
+
 	return i, nil
 }
 
+type BulkInviteParams struct {
+	UserIds   []string
+	InviterId string
+	PartyId   string
+	ValidFor  time.Duration
+}
+
+// Number of invites written per batch, each invite also writes its expiry row
+const inviteBatchSize = 25
+
+// Invites all users with the same expiry, already existing invites are overwritten
+func (r partyParticipantRepository) BulkInvite(ctx context.Context, params BulkInviteParams) ([]datastruct.PartyInvite, error) {
+	validUntil := time.Now().Add(params.ValidFor)
+	ttl := params.ValidFor + expiryLookback
+
+	var res []datastruct.PartyInvite
+	for start := 0; start < len(params.UserIds); start += inviteBatchSize {
+		end := start + inviteBatchSize
+		if end > len(params.UserIds) {
+			end = len(params.UserIds)
+		}
+
+		b := qb.Batch()
+		m := qb.M{}
+		var is []datastruct.PartyInvite
+		for n, uId := range params.UserIds[start:end] {
+			i := datastruct.PartyInvite{
+				UserId:     uId,
+				InviterId:  params.InviterId,
+				PartyId:    params.PartyId,
+				ValidUntil: validUntil,
+			}
+			err := r.val.StructCtx(ctx, i)
+			if err != nil {
+				return res, err
+			}
+
+			ip := fmt.Sprintf("invite%d", n)
+			ep := fmt.Sprintf("expiry%d", n)
+			b.AddWithPrefix(ip, qb.
+				Insert(PARTY_INVITES).
+				Columns(partyInviteMetadata.Columns...).
+				TTL(ttl))
+			b.AddWithPrefix(ep, qb.
+				Insert(PARTY_INVITE_EXPIRIES).
+				Columns(partyInviteExpiryMetadata.Columns...).
+				TTL(ttl))
+
+			m[ip+".user_id"] = i.UserId
+			m[ip+".party_id"] = i.PartyId
+			m[ip+".inviter_id"] = i.InviterId
+			m[ip+".valid_until"] = i.ValidUntil
+			m[ep+".expires_on"] = expiryDay(i.ValidUntil)
+			m[ep+".valid_until"] = i.ValidUntil
+			m[ep+".user_id"] = i.UserId
+			m[ep+".party_id"] = i.PartyId
+			m[ep+".inviter_id"] = i.InviterId
+
+			is = append(is, i)
+		}
+
+		stmt, names := b.ToCql()
+		err := r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindMap(m).
+			ExecRelease()
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, is...)
+	}
+
+	return res, nil
+}
+
 type UserPartyParams struct {
 	UserId  string
 	PartyId string
 }
 
+func (r partyParticipantRepository) GetInvite(ctx context.Context, params UserPartyParams) (res datastruct.PartyInvite, err error) {
+	stmt, names := qb.
+		Select(PARTY_INVITES).
+		Columns(partyInviteMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":  params.UserId,
+			"party_id": params.PartyId,
+		})).
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return res, status.Error(codes.NotFound, "Invite not found")
+	}
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
 func (r partyParticipantRepository) Decline(ctx context.Context, params UserPartyParams) error {
 	stmt, names := qb.
 		Delete(PARTY_INVITES).
@@ -110,30 +319,91 @@ func (r partyParticipantRepository) Decline(ctx context.Context, params UserPart
 }
 
 func (r partyParticipantRepository) Accept(ctx context.Context, params UserPartyParams) error {
-	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	i, err := r.GetInvite(ctx, params)
+	if err != nil {
+		return err
+	}
+	if !i.ValidUntil.IsZero() && i.ValidUntil.Before(time.Now()) {
+		return status.Error(codes.FailedPrecondition, "Invite expired")
+	}
+
+	return r.joinFrom(ctx, PARTY_INVITES, params)
+}
 
-	var err error
+// Adds the user to the participants of the party and removes his row from the given table.
+// The insert is a lightweight transaction so an existing participant keeps his plus-ones and check-in,
+// which is why it can't share a batch with the delete from another partition. Instead the join is undone
+// when the row can't be removed, so a failed call leaves nothing behind and can simply be retried.
+// A participant left over by a call which couldn't undo its join only gets the stale row removed.
+func (r partyParticipantRepository) joinFrom(ctx context.Context, from string, params UserPartyParams) error {
+	p := datastruct.PartyParticipant{
+		UserId:   params.UserId,
+		PartyId:  params.PartyId,
+		JoinedAt: time.Now(),
+	}
 
-	go func() {
-		defer wg.Done()
+	stmt, names := qb.
+		Insert(PARTY_PARTICIPANTS).
+		Unique().
+		Columns(partyParticipantMetadata.Columns...).
+		ToCql()
 
-		tmp := r.Decline(ctx, params)
-		if tmp != nil {
-			err = tmp
-		}
-	}()
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(p).
+		ExecCASRelease()
+	if err != nil {
+		return err
+	}
 
-	go func() {
-		defer wg.Done()
+	stmt, names = qb.
+		Delete(from).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("party_id")).
+		ToCql()
 
-		tmp := r.Join(ctx, params)
-		if tmp != nil {
-			err = tmp
-		}
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":  params.UserId,
+			"party_id": params.PartyId,
+		})).
+		ExecRelease()
+	if err != nil && applied {
+		return r.undoJoin(ctx, p, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		return status.Error(codes.AlreadyExists, "Already joined Party")
+	}
+	return nil
+}
+
+// Removes the participant added by joinFrom unless he was replaced in the meantime and returns the error which caused it
+func (r partyParticipantRepository) undoJoin(ctx context.Context, p datastruct.PartyParticipant, cause error) error {
+	stmt, names := qb.
+		Delete(PARTY_PARTICIPANTS).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		If(qb.EqNamed("joined_at", "old.joined_at")).
+		ToCql()
+
+	_, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id":      p.PartyId,
+			"user_id":       p.UserId,
+			"old.joined_at": p.JoinedAt,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return err
+	}
 
-	}()
-	return err
+	return cause
 }
 
 type GetUserInvitesParams struct {
@@ -167,7 +437,16 @@ func (r partyParticipantRepository) GetUserInvites(ctx context.Context, params G
 		return []datastruct.PartyInvite{}, nil, status.Error(codes.Internal, "No invites found")
 	}
 
-	return res, iter.PageState(), nil
+	// Invites which expired but weren't swept yet should not show up anymore
+	now := time.Now()
+	valid := res[:0]
+	for _, i := range res {
+		if i.ValidUntil.IsZero() || i.ValidUntil.After(now) {
+			valid = append(valid, i)
+		}
+	}
+
+	return valid, iter.PageState(), nil
 }
 
 func (r partyParticipantRepository) Join(ctx context.Context, params UserPartyParams) error {
@@ -183,34 +462,66 @@ func (r partyParticipantRepository) Join(ctx context.Context, params UserPartyPa
 		Columns(partyParticipantMetadata.Columns...).
 		ToCql()
 
-	err := r.sess.
+	applied, err := r.sess.
 		ContextQuery(ctx, stmt, names).
 		BindStruct(p).
-		ExecRelease()
+		ExecCASRelease()
 	if err != nil {
 		return err
 	}
+	if !applied {
+		return status.Error(codes.AlreadyExists, "Already joined Party")
+	}
 	return nil
 }
 
-func (r partyParticipantRepository) Leave(ctx context.Context, params UserPartyParams) error {
+// Returns the removed participant so his plus-ones can be subtracted
+func (r partyParticipantRepository) Leave(ctx context.Context, params UserPartyParams) (datastruct.PartyParticipant, error) {
+	p, err := r.GetParticipant(ctx, params)
+	if err != nil {
+		return datastruct.PartyParticipant{}, err
+	}
+
+	// Users who aren't participants anymore can't stay checked in, removed first so a failure leaves the participant in place
 	stmt, names := qb.
+		Delete(PARTY_CHECK_INS).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PartyId,
+			"user_id":  params.UserId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartyParticipant{}, err
+	}
+
+	stmt, names = qb.
 		Delete(PARTY_PARTICIPANTS).
 		Where(qb.Eq("user_id")).
 		Where(qb.Eq("party_id")).
+		Existing().
 		ToCql()
 
-	err := r.sess.
+	applied, err := r.sess.
 		ContextQuery(ctx, stmt, names).
 		BindMap((qb.M{
 			"user_id":  params.UserId,
 			"party_id": params.PartyId,
 		})).
-		ExecRelease()
+		ExecCASRelease()
 	if err != nil {
-		return err
+		return datastruct.PartyParticipant{}, err
 	}
-	return nil
+	if !applied {
+		return datastruct.PartyParticipant{}, status.Error(codes.NotFound, "Not a participant of the Party")
+	}
+
+	return p, nil
 }
 
 type GetPartyParticipantsParams struct {
@@ -246,3 +557,1052 @@ func (r partyParticipantRepository) GetPartyParticipants(ctx context.Context, pa
 
 	return res, iter.PageState(), nil
 }
+
+func (r partyParticipantRepository) GetParticipant(ctx context.Context, params UserPartyParams) (res datastruct.PartyParticipant, err error) {
+	stmt, names := qb.
+		Select(PARTY_PARTICIPANTS).
+		Columns(partyParticipantMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PartyId,
+			"user_id":  params.UserId,
+		})).
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return res, status.Error(codes.NotFound, "Not a participant of the Party")
+	}
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r partyParticipantRepository) IsParticipant(ctx context.Context, params UserPartyParams) (bool, error) {
+	_, err := r.GetParticipant(ctx, params)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Returns which of the users participate in the party
+func (r partyParticipantRepository) GetParticipantsIn(ctx context.Context, pId string, uIds []string) (res []datastruct.PartyParticipant, err error) {
+	if len(uIds) == 0 {
+		return res, nil
+	}
+
+	stmt, names := qb.
+		Select(PARTY_PARTICIPANTS).
+		Columns(partyParticipantMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		Where(qb.In("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uIds,
+		})).
+		SelectRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+type SetPlusOnesParams struct {
+	UserId     string
+	PartyId    string
+	PlusOnes   int
+	GuestNames []string
+}
+
+// Returns the participant as he was before the change.
+// The update only applies to an existing participant, so a participant who left in the meantime isn't recreated.
+func (r partyParticipantRepository) SetPlusOnes(ctx context.Context, params SetPlusOnesParams) (datastruct.PartyParticipant, error) {
+	p, err := r.GetParticipant(ctx, UserPartyParams{UserId: params.UserId, PartyId: params.PartyId})
+	if err != nil {
+		return datastruct.PartyParticipant{}, err
+	}
+
+	stmt, names := qb.
+		Update(PARTY_PARTICIPANTS).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		Set("plus_ones").
+		Set("guest_names").
+		Existing().
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id":    params.PartyId,
+			"user_id":     params.UserId,
+			"plus_ones":   params.PlusOnes,
+			"guest_names": params.GuestNames,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return datastruct.PartyParticipant{}, err
+	}
+	if !applied {
+		return datastruct.PartyParticipant{}, status.Error(codes.NotFound, "Not a participant of the Party")
+	}
+
+	return p, nil
+}
+
+type CheckInParams struct {
+	UserId      string
+	PartyId     string
+	CheckedInBy string
+}
+
+// Participants can only be checked in once while they participate, leaving removes the check-in.
+// The participant is only updated if he still exists, otherwise the check-in is taken back.
+func (r partyParticipantRepository) CheckIn(ctx context.Context, params CheckInParams) (datastruct.PartyCheckIn, error) {
+	_, err := r.GetParticipant(ctx, UserPartyParams{UserId: params.UserId, PartyId: params.PartyId})
+	if status.Code(err) == codes.NotFound {
+		return datastruct.PartyCheckIn{}, status.Error(codes.FailedPrecondition, "Not a participant of the Party")
+	}
+	if err != nil {
+		return datastruct.PartyCheckIn{}, err
+	}
+
+	c := datastruct.PartyCheckIn{
+		PartyId:     params.PartyId,
+		UserId:      params.UserId,
+		CheckedInAt: time.Now(),
+		CheckedInBy: params.CheckedInBy,
+	}
+
+	err = r.val.StructCtx(ctx, c)
+	if err != nil {
+		return datastruct.PartyCheckIn{}, err
+	}
+
+	stmt, names := qb.
+		Insert(PARTY_CHECK_INS).
+		Unique().
+		Columns(partyCheckInMetadata.Columns...).
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(c).
+		ExecCASRelease()
+	if err != nil {
+		return datastruct.PartyCheckIn{}, err
+	}
+	if !applied {
+		return datastruct.PartyCheckIn{}, status.Error(codes.AlreadyExists, "Already checked in")
+	}
+
+	stmt, names = qb.
+		Update(PARTY_PARTICIPANTS).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		Set("checked_in_at").
+		Set("checked_in_by").
+		Existing().
+		ToCql()
+
+	applied, err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(c).
+		ExecCASRelease()
+	if err != nil {
+		return datastruct.PartyCheckIn{}, err
+	}
+	if applied {
+		return c, nil
+	}
+
+	stmt, names = qb.
+		Delete(PARTY_CHECK_INS).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(c).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartyCheckIn{}, err
+	}
+
+	return datastruct.PartyCheckIn{}, status.Error(codes.FailedPrecondition, "Not a participant of the Party")
+}
+
+type GetCheckedInParams struct {
+	PId   string
+	Page  []byte
+	Limit int
+}
+
+func (r partyParticipantRepository) GetCheckedIn(ctx context.Context, params GetCheckedInParams) (res []datastruct.PartyCheckIn, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_CHECK_INS).
+		Columns(partyCheckInMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PId,
+		}))
+	defer q.Release()
+
+	q.PageState(params.Page)
+	if params.Limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(params.Limit)
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.PartyCheckIn{}, nil, status.Error(codes.Internal, "No check-ins found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+type GetUserParticipationsParams struct {
+	UId   string
+	Page  []byte
+	Limit int
+}
+
+func (r partyParticipantRepository) GetUserParticipations(ctx context.Context, params GetUserParticipationsParams) (res []datastruct.PartyParticipant, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_PARTICIPANTS_BY_USER).
+		Columns(partyParticipantMetadata.Columns...).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id": params.UId,
+		}))
+	defer q.Release()
+
+	q.PageState(params.Page)
+	if params.Limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(params.Limit)
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.PartyParticipant{}, nil, status.Error(codes.Internal, "No participations found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r partyParticipantRepository) GetExpiredInvites(ctx context.Context, day time.Time, until time.Time) (res []datastruct.PartyInviteExpiry, err error) {
+	stmt, names := qb.
+		Select(PARTY_INVITE_EXPIRIES).
+		Columns(partyInviteExpiryMetadata.Columns...).
+		Where(qb.Eq("expires_on")).
+		Where(qb.LtOrEq("valid_until")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"expires_on":  expiryDay(day),
+			"valid_until": until,
+		})).
+		SelectRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Removes the invite if it wasn't accepted, declined or renewed. Returns false if nothing was removed.
+func (r partyParticipantRepository) ExpireInvite(ctx context.Context, e datastruct.PartyInviteExpiry) (bool, error) {
+	stmt, names := qb.
+		Delete(PARTY_INVITES).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("party_id")).
+		If(qb.EqNamed("valid_until", "old.valid_until")).
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"user_id":         e.UserId,
+			"party_id":        e.PartyId,
+			"old.valid_until": e.ValidUntil,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return false, err
+	}
+
+	stmt, names = qb.
+		Delete(PARTY_INVITE_EXPIRIES).
+		Where(qb.Eq("expires_on")).
+		Where(qb.Eq("valid_until")).
+		Where(qb.Eq("user_id")).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(e).
+		ExecRelease()
+	if err != nil {
+		return applied, err
+	}
+
+	return applied, nil
+}
+
+func (r partyParticipantRepository) IncreaseParticipantCount(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Update(PARTY_PARTICIPANT_COUNT).
+		Where(qb.Eq("party_id")).
+		Add("participant_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"participant_count": 1,
+			"party_id":          pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r partyParticipantRepository) DecreaseParticipantCount(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Update(PARTY_PARTICIPANT_COUNT).
+		Where(qb.Eq("party_id")).
+		Remove("participant_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"participant_count": 1,
+			"party_id":          pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r partyParticipantRepository) ChangeGuestCount(ctx context.Context, pId string, delta int) error {
+	stmt, names := qb.
+		Update(PARTY_PARTICIPANT_COUNT).
+		Where(qb.Eq("party_id")).
+		Add("guest_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"guest_count": delta,
+			"party_id":    pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r partyParticipantRepository) IncreaseCheckedInCount(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Update(PARTY_PARTICIPANT_COUNT).
+		Where(qb.Eq("party_id")).
+		Add("checked_in_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"checked_in_count": 1,
+			"party_id":         pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r partyParticipantRepository) DecreaseCheckedInCount(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Update(PARTY_PARTICIPANT_COUNT).
+		Where(qb.Eq("party_id")).
+		Remove("checked_in_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"checked_in_count": 1,
+			"party_id":         pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r partyParticipantRepository) GetParticipantCount(ctx context.Context, pId string) (res datastruct.PartyParticipantCount, err error) {
+	stmt, names := qb.
+		Select(PARTY_PARTICIPANT_COUNT).
+		Columns(partyParticipantCountMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		GetRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r partyParticipantRepository) GetManyParticipantCount(ctx context.Context, pIds []string) (res []datastruct.PartyParticipantCount, err error) {
+	stmt, names := qb.
+		Select(PARTY_PARTICIPANT_COUNT).
+		Columns(partyParticipantCountMetadata.Columns...).
+		Where(qb.In("party_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pIds})).
+		SelectRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r partyParticipantRepository) JoinWaitlist(ctx context.Context, params UserPartyParams) (datastruct.WaitlistEntry, error) {
+	w := datastruct.WaitlistEntry{
+		PartyId:  params.PartyId,
+		UserId:   params.UserId,
+		QueuedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, w)
+	if err != nil {
+		return datastruct.WaitlistEntry{}, err
+	}
+
+	stmt, names := qb.
+		Insert(PARTY_WAITLIST).
+		Unique().
+		Columns(partyWaitlistMetadata.Columns...).
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(w).
+		ExecCASRelease()
+	if err != nil {
+		return datastruct.WaitlistEntry{}, err
+	}
+	if !applied {
+		return datastruct.WaitlistEntry{}, status.Error(codes.AlreadyExists, "Already on the Waitlist")
+	}
+
+	return w, nil
+}
+
+func (r partyParticipantRepository) LeaveWaitlist(ctx context.Context, params UserPartyParams) error {
+	stmt, names := qb.
+		Delete(PARTY_WAITLIST).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PartyId,
+			"user_id":  params.UserId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type GetWaitlistParams struct {
+	PId   string
+	Page  []byte
+	Limit int
+}
+
+func (r partyParticipantRepository) GetWaitlist(ctx context.Context, params GetWaitlistParams) (res []datastruct.WaitlistEntry, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_WAITLIST_BY_QUEUED).
+		Columns(partyWaitlistMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PId,
+		}))
+	defer q.Release()
+
+	q.PageState(params.Page)
+	if params.Limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(params.Limit)
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.WaitlistEntry{}, nil, status.Error(codes.Internal, "No waitlist entries found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+// Removes the longest waiting user from the waitlist and returns it.
+// The ordered view is only eventually consistent and can still list entries which were already removed,
+// those are skipped by the conditional delete on the waitlist itself, so every entry is only popped once.
+// The view is paged through until an entry could be removed, so stale entries can't hide waiting users.
+func (r partyParticipantRepository) PopWaitlist(ctx context.Context, pId string) (datastruct.WaitlistEntry, error) {
+	stmt, names := qb.
+		Select(PARTY_WAITLIST_BY_QUEUED).
+		Columns(partyWaitlistMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		PageSize(10)
+	defer q.Release()
+
+	delStmt, delNames := qb.
+		Delete(PARTY_WAITLIST).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		Existing().
+		ToCql()
+
+	iter := q.Iter()
+	var w datastruct.WaitlistEntry
+	for iter.StructScan(&w) {
+		applied, err := r.sess.
+			ContextQuery(ctx, delStmt, delNames).
+			BindStruct(w).
+			ExecCASRelease()
+		if err != nil {
+			iter.Close()
+			return datastruct.WaitlistEntry{}, err
+		}
+		if applied {
+			iter.Close()
+			return w, nil
+		}
+	}
+
+	err := iter.Close()
+	if err != nil {
+		return datastruct.WaitlistEntry{}, err
+	}
+
+	return datastruct.WaitlistEntry{}, gocql.ErrNotFound
+}
+
+func (r partyParticipantRepository) RequestToJoin(ctx context.Context, params UserPartyParams) (datastruct.PartyJoinRequest, error) {
+	j := datastruct.PartyJoinRequest{
+		PartyId:     params.PartyId,
+		UserId:      params.UserId,
+		RequestedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, j)
+	if err != nil {
+		return datastruct.PartyJoinRequest{}, err
+	}
+
+	stmt, names := qb.
+		Insert(PARTY_JOIN_REQUESTS).
+		Unique().
+		Columns(partyJoinRequestMetadata.Columns...).
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(j).
+		ExecCASRelease()
+	if err != nil {
+		return datastruct.PartyJoinRequest{}, err
+	}
+	if !applied {
+		return datastruct.PartyJoinRequest{}, status.Error(codes.AlreadyExists, "Already requested to join")
+	}
+
+	return j, nil
+}
+
+type GetJoinRequestsParams struct {
+	PId   string
+	Page  []byte
+	Limit int
+}
+
+func (r partyParticipantRepository) GetJoinRequests(ctx context.Context, params GetJoinRequestsParams) (res []datastruct.PartyJoinRequest, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_JOIN_REQUESTS).
+		Columns(partyJoinRequestMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PId,
+		}))
+	defer q.Release()
+
+	q.PageState(params.Page)
+	if params.Limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(params.Limit)
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.PartyJoinRequest{}, nil, status.Error(codes.Internal, "No join requests found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r partyParticipantRepository) getJoinRequest(ctx context.Context, params UserPartyParams) (res datastruct.PartyJoinRequest, err error) {
+	stmt, names := qb.
+		Select(PARTY_JOIN_REQUESTS).
+		Columns(partyJoinRequestMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PartyId,
+			"user_id":  params.UserId,
+		})).
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return res, status.Error(codes.NotFound, "Join request not found")
+	}
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r partyParticipantRepository) ApproveJoinRequest(ctx context.Context, params UserPartyParams) error {
+	_, err := r.getJoinRequest(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return r.joinFrom(ctx, PARTY_JOIN_REQUESTS, params)
+}
+
+func (r partyParticipantRepository) RejectJoinRequest(ctx context.Context, params UserPartyParams) error {
+	stmt, names := qb.
+		Delete(PARTY_JOIN_REQUESTS).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		Existing().
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PartyId,
+			"user_id":  params.UserId,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return status.Error(codes.NotFound, "Join request not found")
+	}
+
+	return nil
+}
+
+type BanParams struct {
+	UserId   string
+	PartyId  string
+	BannedBy string
+}
+
+// Bans the user and drops his pending invite and join request
+func (r partyParticipantRepository) Ban(ctx context.Context, params BanParams) (datastruct.PartyBan, error) {
+	b := datastruct.PartyBan{
+		PartyId:  params.PartyId,
+		UserId:   params.UserId,
+		BannedBy: params.BannedBy,
+		BannedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, b)
+	if err != nil {
+		return datastruct.PartyBan{}, err
+	}
+
+	stmt, names := qb.Batch().
+		AddWithPrefix("ban", qb.
+			Insert(PARTY_BANS).
+			Columns(partyBanMetadata.Columns...)).
+		AddWithPrefix("invite", qb.
+			Delete(PARTY_INVITES).
+			Where(qb.Eq("user_id")).
+			Where(qb.Eq("party_id"))).
+		AddWithPrefix("request", qb.
+			Delete(PARTY_JOIN_REQUESTS).
+			Where(qb.Eq("party_id")).
+			Where(qb.Eq("user_id"))).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"ban.party_id":     b.PartyId,
+			"ban.user_id":      b.UserId,
+			"ban.banned_by":    b.BannedBy,
+			"ban.banned_at":    b.BannedAt,
+			"invite.user_id":   b.UserId,
+			"invite.party_id":  b.PartyId,
+			"request.party_id": b.PartyId,
+			"request.user_id":  b.UserId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartyBan{}, err
+	}
+
+	return b, nil
+}
+
+func (r partyParticipantRepository) Unban(ctx context.Context, params UserPartyParams) error {
+	stmt, names := qb.
+		Delete(PARTY_BANS).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		Existing().
+		ToCql()
+
+	applied, err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PartyId,
+			"user_id":  params.UserId,
+		})).
+		ExecCASRelease()
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return status.Error(codes.NotFound, "Ban not found")
+	}
+
+	return nil
+}
+
+func (r partyParticipantRepository) IsBanned(ctx context.Context, params UserPartyParams) (bool, error) {
+	stmt, names := qb.
+		Select(PARTY_BANS).
+		Columns(partyBanMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	var b datastruct.PartyBan
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PartyId,
+			"user_id":  params.UserId,
+		})).
+		GetRelease(&b)
+	if err == gocql.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type GetBansParams struct {
+	PId   string
+	Page  []byte
+	Limit int
+}
+
+func (r partyParticipantRepository) GetBans(ctx context.Context, params GetBansParams) (res []datastruct.PartyBan, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_BANS).
+		Columns(partyBanMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PId,
+		}))
+	defer q.Release()
+
+	q.PageState(params.Page)
+	if params.Limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(params.Limit)
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.PartyBan{}, nil, status.Error(codes.Internal, "No bans found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+type GetPartyInvitesParams struct {
+	PId   string
+	Page  []byte
+	Limit int
+}
+
+func (r partyParticipantRepository) GetPartyInvites(ctx context.Context, params GetPartyInvitesParams) (res []datastruct.PartyInvite, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_INVITES_BY_PARTY).
+		Columns(partyInviteMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": params.PId,
+		}))
+	defer q.Release()
+
+	q.PageState(params.Page)
+	if params.Limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(params.Limit)
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.PartyInvite{}, nil, status.Error(codes.Internal, "No invites found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+// Deletes the invites together with their expiry rows, invites are spread over one partition per user so they are written in batches
+func (r partyParticipantRepository) RemoveInvites(ctx context.Context, is []datastruct.PartyInvite) error {
+	for start := 0; start < len(is); start += inviteBatchSize {
+		end := start + inviteBatchSize
+		if end > len(is) {
+			end = len(is)
+		}
+
+		b := qb.Batch()
+		m := qb.M{}
+		for n, i := range is[start:end] {
+			ip := fmt.Sprintf("invite%d", n)
+			b.AddWithPrefix(ip, qb.
+				Delete(PARTY_INVITES).
+				Where(qb.Eq("user_id")).
+				Where(qb.Eq("party_id")))
+			m[ip+".user_id"] = i.UserId
+			m[ip+".party_id"] = i.PartyId
+
+			// Invites created before expiries were introduced have no expiry row
+			if i.ValidUntil.IsZero() {
+				continue
+			}
+
+			ep := fmt.Sprintf("expiry%d", n)
+			b.AddWithPrefix(ep, qb.
+				Delete(PARTY_INVITE_EXPIRIES).
+				Where(qb.Eq("expires_on")).
+				Where(qb.Eq("valid_until")).
+				Where(qb.Eq("user_id")).
+				Where(qb.Eq("party_id")))
+			m[ep+".expires_on"] = expiryDay(i.ValidUntil)
+			m[ep+".valid_until"] = i.ValidUntil
+			m[ep+".user_id"] = i.UserId
+			m[ep+".party_id"] = i.PartyId
+		}
+
+		stmt, names := b.ToCql()
+		err := r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindMap(m).
+			ExecRelease()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Deletes only the given rows, a partition delete would make the party_participants_by_user view read the whole partition at once
+func (r partyParticipantRepository) RemoveParticipants(ctx context.Context, pId string, uIds []string) error {
+	if len(uIds) == 0 {
+		return nil
+	}
+
+	stmt, names := qb.
+		Delete(PARTY_PARTICIPANTS).
+		Where(qb.Eq("party_id")).
+		Where(qb.In("user_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uIds,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Deletes only the given waitlist entries, a partition delete would make the party_waitlist_by_queued_at view read the whole partition at once
+func (r partyParticipantRepository) RemoveWaitlistEntries(ctx context.Context, pId string, uIds []string) error {
+	if len(uIds) == 0 {
+		return nil
+	}
+
+	stmt, names := qb.
+		Delete(PARTY_WAITLIST).
+		Where(qb.Eq("party_id")).
+		Where(qb.In("user_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uIds,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Deletes every remaining partition of the party which is only keyed by the party and has no view
+func (r partyParticipantRepository) DeletePartyData(ctx context.Context, pId string) error {
+	stmt, names := qb.Batch().
+		AddWithPrefix("request", qb.
+			Delete(PARTY_JOIN_REQUESTS).
+			Where(qb.Eq("party_id"))).
+		AddWithPrefix("ban", qb.
+			Delete(PARTY_BANS).
+			Where(qb.Eq("party_id"))).
+		AddWithPrefix("checkin", qb.
+			Delete(PARTY_CHECK_INS).
+			Where(qb.Eq("party_id"))).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"request.party_id": pId,
+			"ban.party_id":     pId,
+			"checkin.party_id": pId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r partyParticipantRepository) DeleteParticipantCount(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Delete(PARTY_PARTICIPANT_COUNT).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+)
+
+const (
+	PARTY_ROLES string = "party_roles"
+)
+
+var partyRoleMetadata = table.Metadata{
+	Name:    PARTY_ROLES,
+	Columns: []string{"party_id", "user_id", "role", "assigned_at"},
+	PartKey: []string{"party_id"},
+	SortKey: []string{"user_id"},
+}
+
+type PartyRoleRepository interface {
+	AssignRole(ctx context.Context, pId, uId, role string) (datastruct.PartyRole, error)
+	RevokeRole(ctx context.Context, pId, uId string) error
+	GetRole(ctx context.Context, pId, uId string) (datastruct.PartyRole, error)
+	HasRoles(ctx context.Context, pId string) (bool, error)
+	DeletePartyRoles(ctx context.Context, pId string) error
+}
+
+type partyRoleRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+func (r *partyRoleRepository) AssignRole(ctx context.Context, pId, uId, role string) (datastruct.PartyRole, error) {
+	pr := datastruct.PartyRole{
+		PartyId:    pId,
+		UserId:     uId,
+		Role:       role,
+		AssignedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, pr)
+	if err != nil {
+		return datastruct.PartyRole{}, err
+	}
+
+	stmt, names := qb.
+		Insert(PARTY_ROLES).
+		Columns(partyRoleMetadata.Columns...).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(pr).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartyRole{}, err
+	}
+
+	return pr, nil
+}
+
+func (r *partyRoleRepository) RevokeRole(ctx context.Context, pId, uId string) error {
+	stmt, names := qb.
+		Delete(PARTY_ROLES).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uId,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Returns a role without Role set if the user has no role in the party
+func (r *partyRoleRepository) GetRole(ctx context.Context, pId, uId string) (res datastruct.PartyRole, err error) {
+	stmt, names := qb.
+		Select(PARTY_ROLES).
+		Columns(partyRoleMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uId,
+		})).
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return datastruct.PartyRole{PartyId: pId, UserId: uId}, nil
+	}
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Returns false for parties which have no role stored at all
+func (r *partyRoleRepository) HasRoles(ctx context.Context, pId string) (bool, error) {
+	stmt, names := qb.
+		Select(PARTY_ROLES).
+		Columns("user_id").
+		Where(qb.Eq("party_id")).
+		Limit(1).
+		ToCql()
+
+	var res datastruct.PartyRole
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *partyRoleRepository) DeletePartyRoles(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Delete(PARTY_ROLES).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
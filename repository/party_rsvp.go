@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PARTY_RSVPS           string = "party_rsvps"
+	PARTY_RSVPS_BY_STATUS string = "party_rsvps_by_status"
+	PARTY_RSVP_COUNT      string = "party_rsvp_count"
+)
+
+var partyRsvpMetadata = table.Metadata{
+	Name:    PARTY_RSVPS,
+	Columns: []string{"party_id", "user_id", "status", "updated_at"},
+	PartKey: []string{"party_id"},
+	SortKey: []string{"user_id"},
+}
+
+var partyRsvpCountMetadata = table.Metadata{
+	Name:    PARTY_RSVP_COUNT,
+	Columns: []string{"party_id", "status", "rsvp_count"},
+	PartKey: []string{"party_id"},
+	SortKey: []string{"status"},
+}
+
+type PartyRsvpRepository interface {
+	SetRsvp(ctx context.Context, pId, uId, status string) (datastruct.PartyRsvp, string, error)
+	GetRsvp(ctx context.Context, pId, uId string) (datastruct.PartyRsvp, error)
+	GetRsvpsByStatus(ctx context.Context, pId, status string, page []byte, limit uint64) ([]datastruct.PartyRsvp, []byte, error)
+	IncreaseRsvpCount(ctx context.Context, pId, status string) error
+	DecreaseRsvpCount(ctx context.Context, pId, status string) error
+	GetRsvpCounts(ctx context.Context, pId string) ([]datastruct.PartyRsvpCount, error)
+	GetPartyRsvps(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.PartyRsvp, []byte, error)
+	RemoveRsvps(ctx context.Context, pId string, uIds []string) error
+	DeleteRsvpCounts(ctx context.Context, pId string) error
+}
+
+type partyRsvpRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+// Sets the rsvp status of the user and returns the status it had before, which is empty if there was none
+func (r *partyRsvpRepository) SetRsvp(ctx context.Context, pId, uId, s string) (datastruct.PartyRsvp, string, error) {
+	rsvp := datastruct.PartyRsvp{
+		PartyId:   pId,
+		UserId:    uId,
+		Status:    s,
+		UpdatedAt: time.Now(),
+	}
+
+	err := r.val.StructCtx(ctx, rsvp)
+	if err != nil {
+		return datastruct.PartyRsvp{}, "", err
+	}
+
+	old, err := r.GetRsvp(ctx, pId, uId)
+	if err != nil && err != gocql.ErrNotFound {
+		return datastruct.PartyRsvp{}, "", err
+	}
+	if old.Status == s {
+		return old, old.Status, nil
+	}
+
+	// The conditions make sure the counts stay correct when the status is changed concurrently
+	var q *gocqlx.Queryx
+	if err == gocql.ErrNotFound {
+		stmt, names := qb.
+			Insert(PARTY_RSVPS).
+			Unique().
+			Columns(partyRsvpMetadata.Columns...).
+			ToCql()
+
+		q = r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindStruct(rsvp)
+	} else {
+		stmt, names := qb.
+			Update(PARTY_RSVPS).
+			Where(qb.Eq("party_id")).
+			Where(qb.Eq("user_id")).
+			If(qb.EqNamed("status", "old.status")).
+			Set("status").
+			Set("updated_at").
+			ToCql()
+
+		q = r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindStructMap(rsvp, qb.M{"old.status": old.Status})
+	}
+
+	applied, err := q.ExecCASRelease()
+	if err != nil {
+		return datastruct.PartyRsvp{}, "", err
+	}
+	if !applied {
+		return datastruct.PartyRsvp{}, "", status.Error(codes.Aborted, "Rsvp was changed concurrently")
+	}
+
+	return rsvp, old.Status, nil
+}
+
+func (r *partyRsvpRepository) GetRsvp(ctx context.Context, pId, uId string) (res datastruct.PartyRsvp, err error) {
+	stmt, names := qb.
+		Select(PARTY_RSVPS).
+		Columns(partyRsvpMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("user_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uId,
+		})).
+		GetRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r *partyRsvpRepository) GetRsvpsByStatus(ctx context.Context, pId, s string, page []byte, limit uint64) (res []datastruct.PartyRsvp, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_RSVPS_BY_STATUS).
+		Columns(partyRsvpMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("status")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"status":   s,
+		}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.PartyRsvp{}, nil, errors.New("no rsvps found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+func (r *partyRsvpRepository) IncreaseRsvpCount(ctx context.Context, pId, s string) error {
+	stmt, names := qb.
+		Update(PARTY_RSVP_COUNT).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("status")).
+		Add("rsvp_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"rsvp_count": 1,
+			"party_id":   pId,
+			"status":     s,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *partyRsvpRepository) DecreaseRsvpCount(ctx context.Context, pId, s string) error {
+	stmt, names := qb.
+		Update(PARTY_RSVP_COUNT).
+		Where(qb.Eq("party_id")).
+		Where(qb.Eq("status")).
+		Remove("rsvp_count").
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"rsvp_count": 1,
+			"party_id":   pId,
+			"status":     s,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *partyRsvpRepository) GetRsvpCounts(ctx context.Context, pId string) (res []datastruct.PartyRsvpCount, err error) {
+	stmt, names := qb.
+		Select(PARTY_RSVP_COUNT).
+		Columns(partyRsvpCountMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		SelectRelease(&res)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// Returns all rsvps of the party regardless of their status
+func (r *partyRsvpRepository) GetPartyRsvps(ctx context.Context, pId string, page []byte, limit uint64) (res []datastruct.PartyRsvp, nextPage []byte, err error) {
+	stmt, names := qb.
+		Select(PARTY_RSVPS).
+		Columns(partyRsvpMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	q := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId}))
+	defer q.Release()
+
+	q.PageState(page)
+	if limit == 0 {
+		q.PageSize(20)
+	} else {
+		q.PageSize(int(limit))
+	}
+
+	iter := q.Iter()
+	err = iter.Select(&res)
+	if err != nil {
+		return []datastruct.PartyRsvp{}, nil, errors.New("no rsvps found")
+	}
+
+	return res, iter.PageState(), nil
+}
+
+// Deletes only the given rows, a partition delete would make the party_rsvps_by_status view read the whole partition at once
+func (r *partyRsvpRepository) RemoveRsvps(ctx context.Context, pId string, uIds []string) error {
+	if len(uIds) == 0 {
+		return nil
+	}
+
+	stmt, names := qb.
+		Delete(PARTY_RSVPS).
+		Where(qb.Eq("party_id")).
+		Where(qb.In("user_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{
+			"party_id": pId,
+			"user_id":  uIds,
+		})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *partyRsvpRepository) DeleteRsvpCounts(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Delete(PARTY_RSVP_COUNT).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/go-playground/validator/v10"
+	"github.com/gocql/gocql"
+	"github.com/scylladb/gocqlx/v2"
+	"github.com/scylladb/gocqlx/v2/qb"
+	"github.com/scylladb/gocqlx/v2/table"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	PARTY_SETTINGS string = "party_settings"
+)
+
+var partySettingsMetadata = table.Metadata{
+	Name:    PARTY_SETTINGS,
+	Columns: []string{"party_id", "capacity", "private", "allow_guest_invites", "max_plus_ones", "taken_seats"},
+	PartKey: []string{"party_id"},
+}
+
+type PartySettingsRepository interface {
+	GetPartySettings(ctx context.Context, pId string) (datastruct.PartySettings, error)
+	SetCapacity(ctx context.Context, pId string, capacity int) (datastruct.PartySettings, error)
+	SetPrivate(ctx context.Context, pId string, private bool) (datastruct.PartySettings, error)
+	SetAllowGuestInvites(ctx context.Context, pId string, allow bool) (datastruct.PartySettings, error)
+	SetMaxPlusOnes(ctx context.Context, pId string, max int) (datastruct.PartySettings, error)
+	ReserveSeats(ctx context.Context, pId string, seats int) error
+	ReleaseSeats(ctx context.Context, pId string, seats int) error
+	DeletePartySettings(ctx context.Context, pId string) error
+}
+
+// How often a seat change is retried when concurrent joins of the same party interfere
+const maxSeatChangeAttempts = 10
+
+type partySettingsRepository struct {
+	sess *gocqlx.Session
+	val  *validator.Validate
+}
+
+// Returns the default settings if none were configured for the party
+func (r *partySettingsRepository) GetPartySettings(ctx context.Context, pId string) (res datastruct.PartySettings, err error) {
+	stmt, names := qb.
+		Select(PARTY_SETTINGS).
+		Columns(partySettingsMetadata.Columns...).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		GetRelease(&res)
+	if err == gocql.ErrNotFound {
+		return datastruct.PartySettings{PartyId: pId}, nil
+	}
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (r *partySettingsRepository) SetCapacity(ctx context.Context, pId string, capacity int) (datastruct.PartySettings, error) {
+	s, err := r.GetPartySettings(ctx, pId)
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+	s.Capacity = capacity
+
+	err = r.val.StructCtx(ctx, s)
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+
+	stmt, names := qb.
+		Update(PARTY_SETTINGS).
+		Where(qb.Eq("party_id")).
+		Set("capacity").
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(s).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+
+	return s, nil
+}
+
+// Members of private parties need to be approved by the host
+func (r *partySettingsRepository) SetPrivate(ctx context.Context, pId string, private bool) (datastruct.PartySettings, error) {
+	s, err := r.GetPartySettings(ctx, pId)
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+	s.Private = private
+
+	stmt, names := qb.
+		Update(PARTY_SETTINGS).
+		Where(qb.Eq("party_id")).
+		Set("private").
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(s).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+
+	return s, nil
+}
+
+func (r *partySettingsRepository) SetAllowGuestInvites(ctx context.Context, pId string, allow bool) (datastruct.PartySettings, error) {
+	s, err := r.GetPartySettings(ctx, pId)
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+	s.AllowGuestInvites = allow
+
+	stmt, names := qb.
+		Update(PARTY_SETTINGS).
+		Where(qb.Eq("party_id")).
+		Set("allow_guest_invites").
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(s).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+
+	return s, nil
+}
+
+func (r *partySettingsRepository) SetMaxPlusOnes(ctx context.Context, pId string, max int) (datastruct.PartySettings, error) {
+	s, err := r.GetPartySettings(ctx, pId)
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+	s.MaxPlusOnes = max
+
+	err = r.val.StructCtx(ctx, s)
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+
+	stmt, names := qb.
+		Update(PARTY_SETTINGS).
+		Where(qb.Eq("party_id")).
+		Set("max_plus_ones").
+		ToCql()
+
+	err = r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindStruct(s).
+		ExecRelease()
+	if err != nil {
+		return datastruct.PartySettings{}, err
+	}
+
+	return s, nil
+}
+
+// Takes the seats if the party has enough of them left, parties without a capacity count them anyway
+// so a capacity set later on applies to everybody who already joined
+func (r *partySettingsRepository) ReserveSeats(ctx context.Context, pId string, seats int) error {
+	return r.changeSeats(ctx, pId, seats)
+}
+
+func (r *partySettingsRepository) ReleaseSeats(ctx context.Context, pId string, seats int) error {
+	return r.changeSeats(ctx, pId, -seats)
+}
+
+type partySeats struct {
+	Capacity   int  `db:"capacity"`
+	TakenSeats *int `db:"taken_seats"`
+}
+
+func (r *partySettingsRepository) changeSeats(ctx context.Context, pId string, delta int) error {
+	for i := 0; i < maxSeatChangeAttempts; i++ {
+		var s partySeats
+		stmt, names := qb.
+			Select(PARTY_SETTINGS).
+			Columns("capacity", "taken_seats").
+			Where(qb.Eq("party_id")).
+			ToCql()
+
+		err := r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindMap((qb.M{"party_id": pId})).
+			GetRelease(&s)
+		if err != nil && err != gocql.ErrNotFound {
+			return err
+		}
+
+		// Parties joined before seats were tracked start from their participant count
+		taken := 0
+		if s.TakenSeats != nil {
+			taken = *s.TakenSeats
+		} else {
+			taken, err = r.countedSeats(ctx, pId)
+			if err != nil {
+				return err
+			}
+		}
+
+		next := taken + delta
+		if next < 0 {
+			next = 0
+		}
+		if delta > 0 && s.Capacity > 0 && next > s.Capacity {
+			return status.Error(codes.ResourceExhausted, "Party is full")
+		}
+
+		stmt, names = qb.
+			Update(PARTY_SETTINGS).
+			Set("taken_seats").
+			Where(qb.Eq("party_id")).
+			If(qb.EqNamed("taken_seats", "old.taken_seats")).
+			ToCql()
+
+		applied, err := r.sess.
+			ContextQuery(ctx, stmt, names).
+			BindMap((qb.M{
+				"taken_seats":     next,
+				"party_id":        pId,
+				"old.taken_seats": s.TakenSeats,
+			})).
+			ExecCASRelease()
+		if err != nil {
+			return err
+		}
+		if applied {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Aborted, "Party is busy, try again")
+}
+
+func (r *partySettingsRepository) countedSeats(ctx context.Context, pId string) (int, error) {
+	var c datastruct.PartyParticipantCount
+	stmt, names := qb.
+		Select(PARTY_PARTICIPANT_COUNT).
+		Columns("participant_count", "guest_count").
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		GetRelease(&c)
+	if err == gocql.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return int(c.ParticipantCount + c.GuestCount), nil
+}
+
+func (r *partySettingsRepository) DeletePartySettings(ctx context.Context, pId string) error {
+	stmt, names := qb.
+		Delete(PARTY_SETTINGS).
+		Where(qb.Eq("party_id")).
+		ToCql()
+
+	err := r.sess.
+		ContextQuery(ctx, stmt, names).
+		BindMap((qb.M{"party_id": pId})).
+		ExecRelease()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) AcceptPartyInvite(ctx context.Context, req *rg.AcceptPartyInviteRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	err = s.checkNotBanned(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.reserveSeats(ctx, req.PartyId, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pp.Accept(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		s.releaseSeats(ctx, req.PartyId, 1)
+		return nil, utils.HandleError(err)
+	}
+
+	s.stream.PublishEvent(&events.PartyJoined{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) AddCloseFriend(ctx context.Context, req *rg.AddCloseFriendRequest) (*rg.CloseFriend, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.FriendId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Friend id")
+	}
+
+	fr, err := s.fs.GetFriendRelation(ctx, req.UserId, req.FriendId)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, utils.HandleError(err)
+	}
+	if err != nil || !fr.Accepted {
+		return nil, status.Error(codes.FailedPrecondition, "Only friends can be added as close friends")
+	}
+
+	cf, err := s.cf.AddCloseFriend(ctx, req.UserId, req.FriendId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return cf.ToGRPCCloseFriend(), nil
+}
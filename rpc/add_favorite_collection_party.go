@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) AddFavoriteCollectionParty(ctx context.Context, req *rg.FavoriteCollectionPartyRequest) (*rg.FavoriteCollectionParty, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.CollectionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Collection id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	_, err = s.fc.GetCollection(ctx, req.UserId, req.CollectionId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Collection not found")
+	}
+
+	err = s.ensureFavorited(ctx, req.UserId, req.PartyId)
+	if err != nil {
+		return nil, err
+	}
+
+	cp, err := s.fc.AddCollectionParty(ctx, req.UserId, req.CollectionId, req.PartyId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return cp.ToGRPCFavoriteCollectionParty(), nil
+}
+
+// Saving a party to a collection also favorites it through the same call as FavorParty,
+// so favorite counts are updated the same way for both
+func (s relationServer) ensureFavorited(ctx context.Context, uId, pId string) error {
+	_, err := s.fp.FavorParty(ctx, datastruct.FavoriteParty{
+		UserId:      uId,
+		PartyId:     pId,
+		FavoritedAt: time.Now(),
+	})
+	if err != nil {
+		return utils.HandleError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) AddFriendGroupMember(ctx context.Context, req *rg.AddFriendGroupMemberRequest) (*rg.FriendGroupMember, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.GroupId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Group id")
+	}
+	_, err = ksuid.Parse(req.MemberId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Member id")
+	}
+
+	_, err = s.fg.GetGroup(ctx, req.UserId, req.GroupId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Group not found")
+	}
+
+	fr, err := s.fs.GetFriendRelation(ctx, req.UserId, req.MemberId)
+	if err != nil || !fr.Accepted {
+		return nil, status.Error(codes.FailedPrecondition, "Only friends can be added to a group")
+	}
+
+	m, err := s.fg.AddGroupMember(ctx, req.GroupId, req.MemberId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return m.ToGRPCFriendGroupMember(), nil
+}
@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) ApproveJoinRequest(ctx context.Context, req *rg.ApproveJoinRequestRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.ApproverId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Approver id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	_, err = s.checkHost(ctx, req.PartyId, req.ApproverId)
+	if err != nil {
+		return nil, err
+	}
+
+	// A requester who joined through an invite or link in the meantime takes no extra seat,
+	// approving only drops the stale request and fails with AlreadyExists without publishing PartyJoined
+	ok, err := s.pp.IsParticipant(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+	if !ok {
+		err = s.reserveSeats(ctx, req.PartyId, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.pp.ApproveJoinRequest(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		if !ok {
+			s.releaseSeats(ctx, req.PartyId, 1)
+		}
+		return nil, utils.HandleError(err)
+	}
+
+	s.stream.PublishEvent(&events.PartyJoinRequestApproved{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	s.stream.PublishEvent(&events.PartyJoined{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	pg "github.com/clubo-app/protobuf/party"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) AssignRole(ctx context.Context, req *rg.AssignRoleRequest) (*rg.PartyRole, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.AssignerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Assigner id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	if req.Role != rg.Role_CO_HOST {
+		return nil, status.Error(codes.InvalidArgument, "Only the Co-Host role can be assigned")
+	}
+
+	r, err := s.checkHost(ctx, req.PartyId, req.AssignerId)
+	if err != nil {
+		return nil, err
+	}
+	if r.Role != datastruct.ROLE_HOST {
+		return nil, status.Error(codes.PermissionDenied, "Only the Host can assign roles")
+	}
+
+	ok, err := s.pp.IsParticipant(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+	if !ok {
+		return nil, status.Error(codes.FailedPrecondition, "User is not a participant of this Party")
+	}
+
+	pr, err := s.ro.AssignRole(ctx, req.PartyId, req.UserId, datastruct.RoleFromGRPC(req.Role))
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return pr.ToGRPCPartyRole(), nil
+}
+
+// Returns the role of the user if he is a host or co-host of the party
+func (s relationServer) checkHost(ctx context.Context, pId, uId string) (datastruct.PartyRole, error) {
+	r, err := s.getRole(ctx, pId, uId)
+	if err != nil {
+		return r, utils.HandleError(err)
+	}
+	if !r.IsHost() {
+		return r, status.Error(codes.PermissionDenied, "Not a Host of this Party")
+	}
+
+	return r, nil
+}
+
+// Parties created before roles existed have no Host stored, for them the owner is looked up
+// at the party service and stored as Host the first time he needs his role
+func (s relationServer) getRole(ctx context.Context, pId, uId string) (datastruct.PartyRole, error) {
+	r, err := s.ro.GetRole(ctx, pId, uId)
+	if err != nil || r.Role != "" {
+		return r, err
+	}
+
+	ok, err := s.ro.HasRoles(ctx, pId)
+	if err != nil || ok {
+		return r, err
+	}
+
+	p, err := s.pc.GetParty(ctx, &pg.GetPartyRequest{PartyId: pId})
+	if err != nil {
+		return r, err
+	}
+	if p.UserId != uId {
+		return r, nil
+	}
+
+	return s.ro.AssignRole(ctx, pId, uId, datastruct.ROLE_HOST)
+}
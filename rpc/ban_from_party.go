@@ -0,0 +1,94 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) BanFromParty(ctx context.Context, req *rg.BanFromPartyRequest) (*rg.PartyBan, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.BannerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Banner id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	r, err := s.checkHost(ctx, req.PartyId, req.BannerId)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkRemovable(ctx, r, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := s.pp.Ban(ctx, repository.BanParams{
+		UserId:   req.UserId,
+		PartyId:  req.PartyId,
+		BannedBy: req.BannerId,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	params := repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	}
+
+	err = s.pp.LeaveWaitlist(ctx, params)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	err = s.ro.RevokeRole(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	// The user doesn't have to be a participant to be banned
+	p, err := s.pp.Leave(ctx, params)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, utils.HandleError(err)
+	}
+	if err == nil {
+		s.afterRemoval(ctx, p)
+	}
+
+	s.stream.PublishEvent(&events.PartyBanned{
+		UserId:   req.UserId,
+		PartyId:  req.PartyId,
+		BannerId: req.BannerId,
+	})
+
+	return b.ToGRPCPartyBan(), nil
+}
+
+// Returns PermissionDenied if the user is banned from the party
+func (s relationServer) checkNotBanned(ctx context.Context, pId, uId string) error {
+	banned, err := s.pp.IsBanned(ctx, repository.UserPartyParams{
+		UserId:  uId,
+		PartyId: pId,
+	})
+	if err != nil {
+		return utils.HandleError(err)
+	}
+	if banned {
+		return status.Error(codes.PermissionDenied, "Banned from this Party")
+	}
+
+	return nil
+}
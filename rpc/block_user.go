@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) BlockUser(ctx context.Context, req *rg.BlockUserRequest) (*rg.Block, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.BlockedId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Blocked id")
+	}
+	if req.UserId == req.BlockedId {
+		return nil, status.Error(codes.InvalidArgument, "Can't block yourself")
+	}
+
+	b, err := s.bl.Block(ctx, req.UserId, req.BlockedId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return b.ToGRPCBlock(), nil
+}
@@ -0,0 +1,177 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxBulkInvitees = 500
+
+func (s relationServer) BulkInvite(ctx context.Context, req *rg.BulkInviteRequest) (*rg.BulkInviteResponse, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	_, err = ksuid.Parse(req.InviterId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Inviter id")
+	}
+
+	err = s.checkCanInvite(ctx, req.PartyId, req.InviterId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group members and all friends are already known to be friends
+	checkFriends := false
+	var uIds []string
+	switch {
+	case req.AllFriends:
+		uIds, err = s.getAllFriendIds(ctx, req.InviterId)
+		if err != nil {
+			return nil, err
+		}
+	case req.GroupId != "":
+		_, err = ksuid.Parse(req.GroupId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid Group id")
+		}
+
+		uIds, err = s.getGroupFriendIds(ctx, req.InviterId, req.GroupId)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		for _, uId := range req.UserIds {
+			_, err = ksuid.Parse(uId)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+			}
+		}
+		uIds = req.UserIds
+		checkFriends = true
+	}
+	if len(uIds) > maxBulkInvitees {
+		return nil, status.Errorf(codes.InvalidArgument, "Can't invite more than %d users at once", maxBulkInvitees)
+	}
+
+	var res []*rg.BulkInviteResult
+	var invitees []string
+	seen := make(map[string]bool)
+	for _, uId := range uIds {
+		if seen[uId] {
+			continue
+		}
+		seen[uId] = true
+
+		st, err := s.bulkInviteStatus(ctx, req.PartyId, req.InviterId, uId, checkFriends)
+		if err != nil {
+			return nil, utils.HandleError(err)
+		}
+		if st != rg.BulkInviteStatus_INVITED {
+			res = append(res, &rg.BulkInviteResult{UserId: uId, Status: st})
+			continue
+		}
+		invitees = append(invitees, uId)
+	}
+
+	is, err := s.pp.BulkInvite(ctx, repository.BulkInviteParams{
+		UserIds:   invitees,
+		InviterId: req.InviterId,
+		PartyId:   req.PartyId,
+		ValidFor:  partyInviteValidFor,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	for _, i := range is {
+		res = append(res, &rg.BulkInviteResult{
+			UserId: i.UserId,
+			Status: rg.BulkInviteStatus_INVITED,
+			Invite: i.ToGRPCPartyInvite(),
+		})
+	}
+
+	return &rg.BulkInviteResponse{Results: res}, nil
+}
+
+// Returns INVITED if the user can be invited, otherwise the reason he is skipped
+func (s relationServer) bulkInviteStatus(ctx context.Context, pId, inviterId, uId string, checkFriend bool) (rg.BulkInviteStatus, error) {
+	if checkFriend {
+		fr, err := s.fs.GetFriendRelation(ctx, inviterId, uId)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return 0, err
+		}
+		if err != nil || !fr.Accepted {
+			return rg.BulkInviteStatus_NOT_FRIEND, nil
+		}
+	}
+
+	blocked, err := s.bl.IsBlocked(ctx, inviterId, uId)
+	if err != nil {
+		return 0, err
+	}
+	if blocked {
+		return rg.BulkInviteStatus_BLOCKED, nil
+	}
+
+	params := repository.UserPartyParams{
+		UserId:  uId,
+		PartyId: pId,
+	}
+
+	banned, err := s.pp.IsBanned(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	if banned {
+		return rg.BulkInviteStatus_BANNED, nil
+	}
+
+	ok, err := s.pp.IsParticipant(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return rg.BulkInviteStatus_ALREADY_PARTICIPATING, nil
+	}
+
+	i, err := s.pp.GetInvite(ctx, params)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return 0, err
+	}
+	if err == nil && i.ValidUntil.After(time.Now()) {
+		return rg.BulkInviteStatus_ALREADY_INVITED, nil
+	}
+
+	return rg.BulkInviteStatus_INVITED, nil
+}
+
+// Returns the ids of all accepted friends of the user
+func (s relationServer) getAllFriendIds(ctx context.Context, uId string) ([]string, error) {
+	var res []string
+	var p []byte
+	for {
+		fs, next, err := s.fs.GetFriends(ctx, uId, p, 100)
+		if err != nil {
+			return nil, utils.HandleError(err)
+		}
+
+		for _, f := range fs {
+			res = append(res, f.FriendId)
+		}
+
+		if len(next) == 0 {
+			return res, nil
+		}
+		p = next
+	}
+}
@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) CheckIn(ctx context.Context, req *rg.CheckInRequest) (*rg.PartyCheckIn, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.CheckedInBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Checked In By id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	_, err = s.checkHost(ctx, req.PartyId, req.CheckedInBy)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.pp.CheckIn(ctx, repository.CheckInParams{
+		UserId:      req.UserId,
+		PartyId:     req.PartyId,
+		CheckedInBy: req.CheckedInBy,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	s.stream.PublishEvent(&events.PartyCheckedIn{
+		UserId:      req.UserId,
+		PartyId:     req.PartyId,
+		CheckedInBy: req.CheckedInBy,
+	})
+
+	return c.ToGRPCPartyCheckIn(), nil
+}
@@ -0,0 +1,25 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) CreateFavoriteCollection(ctx context.Context, req *rg.CreateFavoriteCollectionRequest) (*rg.FavoriteCollection, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+
+	c, err := s.fc.CreateCollection(ctx, req.UserId, req.Name)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return c.ToGRPCFavoriteCollection(), nil
+}
@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"unicode/utf8"
 
 	"github.com/clubo-app/packages/utils"
 	cg "github.com/clubo-app/protobuf/common"
@@ -20,8 +21,17 @@ func (s relationServer) CreateFriendRequest(ctx context.Context, req *rg.CreateF
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "Invalid Friend id")
 	}
+	if req.SourcePartyId != "" {
+		_, err = ksuid.Parse(req.SourcePartyId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid Source Party id")
+		}
+	}
+	if utf8.RuneCountInString(req.Message) > 200 {
+		return nil, status.Error(codes.InvalidArgument, "Message can't be longer than 200 characters")
+	}
 
-	err = s.fs.CreateFriendRequest(ctx, req.UserId, req.FriendId)
+	err = s.fs.CreateFriendRequest(ctx, req.UserId, req.FriendId, req.Message, req.SourcePartyId)
 	if err != nil {
 		return nil, utils.HandleError(err)
 	}
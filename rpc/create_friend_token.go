@@ -0,0 +1,55 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	friendTokenValidFor = 5 * time.Minute
+	friendTokenPrefix   = "friend"
+)
+
+// Friend tokens aren't stored, the owner and expiry are part of the signed payload
+func (s relationServer) CreateFriendToken(ctx context.Context, req *rg.CreateFriendTokenRequest) (*rg.FriendToken, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+
+	expiresAt := time.Now().Add(friendTokenValidFor)
+	payload := strings.Join([]string{friendTokenPrefix, req.UserId, strconv.FormatInt(expiresAt.Unix(), 10)}, ":")
+
+	return &rg.FriendToken{
+		Token:     s.tk.Sign(payload),
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+// Returns the owner of the friend token and when it expires
+func (s relationServer) parseFriendToken(token string) (string, time.Time, error) {
+	payload, err := s.tk.Verify(token)
+	if err != nil {
+		return "", time.Time{}, status.Error(codes.InvalidArgument, "Invalid Token")
+	}
+
+	parts := strings.Split(payload, ":")
+	if len(parts) != 3 || parts[0] != friendTokenPrefix {
+		return "", time.Time{}, status.Error(codes.InvalidArgument, "Invalid Token")
+	}
+
+	exp, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", time.Time{}, status.Error(codes.InvalidArgument, "Invalid Token")
+	}
+
+	return parts[1], time.Unix(exp, 0), nil
+}
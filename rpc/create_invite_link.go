@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) CreateInviteLink(ctx context.Context, req *rg.CreateInviteLinkRequest) (*rg.PartyInviteLink, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	_, err = ksuid.Parse(req.CreatorId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Creator id")
+	}
+
+	var expiresAt time.Time
+	if req.ExpiresAt != nil {
+		expiresAt = req.ExpiresAt.AsTime()
+		if expiresAt.Before(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "Expiry has to be in the future")
+		}
+	}
+
+	err = s.checkCanInvite(ctx, req.PartyId, req.CreatorId)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := s.il.CreateInviteLink(ctx, repository.CreateInviteLinkParams{
+		PartyId:   req.PartyId,
+		CreatorId: req.CreatorId,
+		MaxUses:   int(req.MaxUses),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return l.ToGRPCPartyInviteLink(s.tk.Sign(l.LinkId)), nil
+}
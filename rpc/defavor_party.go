@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"log"
 
 	"github.com/clubo-app/packages/utils"
 	cg "github.com/clubo-app/protobuf/common"
@@ -22,7 +23,19 @@ func (s relationServer) DefavorParty(ctx context.Context, req *rg.FavorPartyRequ
 		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
 	}
 
-	err = s.fp.DefavorParty(ctx, req.UserId, req.PartyId)
+	fp, err := s.fp.DefavorParty(ctx, req.UserId, req.PartyId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	// Only here the time of the favorite is known, so the hourly bucket it was counted in is decreased right away
+	err = s.fp.DecreaseHourlyFavoriteCount(ctx, fp.PartyId, fp.FavoritedAt)
+	if err != nil {
+		log.Println("Error decreasing Hourly Count: ", err)
+	}
+
+	// An unfavorited party can't stay saved in any collection
+	err = s.fc.RemovePartyFromCollections(ctx, req.UserId, req.PartyId)
 	if err != nil {
 		return nil, utils.HandleError(err)
 	}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetCheckedIn(ctx context.Context, req *rg.GetCheckedInRequest) (*rg.PagedPartyCheckIns, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	cs, p, err := s.pp.GetCheckedIn(ctx, repository.GetCheckedInParams{
+		PId:   req.PartyId,
+		Page:  p,
+		Limit: int(req.Limit),
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	var res []*rg.PartyCheckIn
+	for _, c := range cs {
+		res = append(res, c.ToGRPCPartyCheckIn())
+	}
+
+	return &rg.PagedPartyCheckIns{CheckIns: res, NextPage: nextPage}, nil
+}
@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetFavoriteCollectionParties(ctx context.Context, req *rg.GetFavoriteCollectionPartiesRequest) (*rg.PagedFavoriteCollectionParties, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.CollectionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Collection id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	_, err = s.fc.GetCollection(ctx, req.UserId, req.CollectionId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Collection not found")
+	}
+
+	ps, p, err := s.fc.GetCollectionParties(ctx, req.CollectionId, p, req.Limit)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	var res []*rg.FavoriteCollectionParty
+	for _, cp := range ps {
+		res = append(res, cp.ToGRPCFavoriteCollectionParty())
+	}
+
+	return &rg.PagedFavoriteCollectionParties{Parties: res, NextPage: nextPage}, nil
+}
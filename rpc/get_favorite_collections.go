@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetFavoriteCollections(ctx context.Context, req *rg.GetFavoriteCollectionsRequest) (*rg.PagedFavoriteCollections, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	cs, p, err := s.fc.GetCollections(ctx, req.UserId, p, req.Limit)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	var res []*rg.FavoriteCollection
+	for _, c := range cs {
+		res = append(res, c.ToGRPCFavoriteCollection())
+	}
+
+	return &rg.PagedFavoriteCollections{Collections: res, NextPage: nextPage}, nil
+}
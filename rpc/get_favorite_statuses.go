@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxFavoriteStatuses = 100
+
+func (s relationServer) GetFavoriteStatuses(ctx context.Context, req *rg.GetFavoriteStatusesRequest) (*rg.GetFavoriteStatusesResponse, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	if len(req.PartyIds) > maxFavoriteStatuses {
+		return nil, status.Errorf(codes.InvalidArgument, "Can't look up more than %d parties at once", maxFavoriteStatuses)
+	}
+	for _, pId := range req.PartyIds {
+		_, err = ksuid.Parse(pId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+		}
+	}
+
+	fps, err := s.fp.GetFavoritesIn(ctx, req.UserId, req.PartyIds)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	// Every requested party is part of the result, even if it isn't favorited
+	res := make(map[string]*rg.FavoriteStatus, len(req.PartyIds))
+	for _, pId := range req.PartyIds {
+		res[pId] = &rg.FavoriteStatus{Favorited: false}
+	}
+	for _, fp := range fps {
+		res[fp.PartyId] = &rg.FavoriteStatus{
+			Favorited:   true,
+			FavoritedAt: timestamppb.New(fp.FavoritedAt),
+		}
+	}
+
+	return &rg.GetFavoriteStatusesResponse{Statuses: res}, nil
+}
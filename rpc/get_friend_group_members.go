@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetFriendGroupMembers(ctx context.Context, req *rg.GetFriendGroupMembersRequest) (*rg.PagedFriendGroupMembers, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.GroupId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Group id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	_, err = s.fg.GetGroup(ctx, req.UserId, req.GroupId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Group not found")
+	}
+
+	ms, p, err := s.fg.GetGroupMembers(ctx, req.GroupId, p, req.Limit)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	var res []*rg.FriendGroupMember
+	for _, m := range ms {
+		res = append(res, m.ToGRPCFriendGroupMember())
+	}
+
+	return &rg.PagedFriendGroupMembers{Members: res, NextPage: nextPage}, nil
+}
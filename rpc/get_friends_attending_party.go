@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Number of friends which are matched against the party per query
+const friendScanPageSize = 100
+
+func (s relationServer) GetFriendsAttendingParty(ctx context.Context, req *rg.GetFriendsAttendingPartyRequest) (*rg.PagedFriendsAttendingParty, error) {
+	_, err := ksuid.Parse(req.ViewerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Viewer id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 20
+	}
+
+	// The total is counted while scanning for the first page, later pages don't repeat it
+	ps := make(map[string]datastruct.PartyParticipant)
+	fIds, p, count, err := s.scanFriends(ctx, req.ViewerId, p, limit, req.NextPage == "", func(fIds []string) ([]string, error) {
+		res, err := s.pp.GetParticipantsIn(ctx, req.PartyId, fIds)
+		var matched []string
+		for _, pa := range res {
+			ps[pa.UserId] = pa
+			matched = append(matched, pa.UserId)
+		}
+		return matched, err
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	var res []*rg.PartyParticipant
+	for _, fId := range fIds {
+		res = append(res, ps[fId].ToGRPCPartyParticipant())
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	return &rg.PagedFriendsAttendingParty{Participants: res, Count: uint32(count), NextPage: nextPage}, nil
+}
+
+// Walks the friends of the user from the given position and passes their ids to match, which returns the matching ones.
+// Returns the first limit matching friends in the order of the friend list and the position right after the last of them.
+// With count set all remaining friends are walked as well and the total number of matches is returned.
+func (s relationServer) scanFriends(ctx context.Context, uId string, pos []byte, limit int, count bool, match func(fIds []string) ([]string, error)) (kept []string, next []byte, total int, err error) {
+	page, skip, err := decodeFriendScanPos(pos)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	for {
+		fs, fsNext, err := s.fs.GetFriends(ctx, uId, page, friendScanPageSize)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if skip > len(fs) {
+			skip = len(fs)
+		}
+
+		var fIds []string
+		for _, f := range fs[skip:] {
+			fIds = append(fIds, f.FriendId)
+		}
+
+		matched, err := match(fIds)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		total += len(matched)
+
+		isMatch := make(map[string]bool)
+		for _, m := range matched {
+			isMatch[m] = true
+		}
+
+		for i, fId := range fIds {
+			if !isMatch[fId] || len(kept) == limit {
+				continue
+			}
+			kept = append(kept, fId)
+
+			if len(kept) == limit {
+				switch {
+				case skip+i+1 < len(fs):
+					next = encodeFriendScanPos(page, skip+i+1)
+				case len(fsNext) > 0:
+					next = encodeFriendScanPos(fsNext, 0)
+				}
+			}
+		}
+
+		if len(fsNext) == 0 || (len(kept) == limit && !count) {
+			return kept, next, total, nil
+		}
+		page = fsNext
+		skip = 0
+	}
+}
+
+// A position in the friend list is the page state of a page of friends and the number of friends
+// already used from that page, so a scan can continue right after the last returned friend
+func encodeFriendScanPos(page []byte, skip int) []byte {
+	buf := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(page))
+	n := binary.PutUvarint(buf, uint64(skip))
+	return append(buf[:n], page...)
+}
+
+func decodeFriendScanPos(pos []byte) ([]byte, int, error) {
+	if len(pos) == 0 {
+		return nil, 0, nil
+	}
+
+	skip, n := binary.Uvarint(pos)
+	if n <= 0 || skip > friendScanPageSize {
+		return nil, 0, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	return pos[n:], int(skip), nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/clubo-app/packages/utils"
 	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -16,7 +17,15 @@ func (s relationServer) GetFriends(ctx context.Context, req *rg.GetFriendsReques
 		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
 	}
 
-	fs, p, err := s.fs.GetFriends(ctx, req.UserId, p, req.Limit)
+	var fs []datastruct.FriendRelation
+	switch req.Order {
+	case rg.FriendOrder_ACCEPTED_AT:
+		fs, p, err = s.fs.GetFriendsByAcceptedAt(ctx, req.UserId, p, req.Limit)
+	case rg.FriendOrder_DISPLAY_NAME:
+		fs, p, err = s.fs.GetFriendsByDisplayName(ctx, req.UserId, p, req.Limit)
+	default:
+		fs, p, err = s.fs.GetFriends(ctx, req.UserId, p, req.Limit)
+	}
 	if err != nil {
 		return nil, utils.HandleError(err)
 	}
@@ -0,0 +1,57 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetFriendsWhoFavoritedParty(ctx context.Context, req *rg.GetFriendsWhoFavoritedPartyRequest) (*rg.PagedFriendsWhoFavoritedParty, error) {
+	_, err := ksuid.Parse(req.ViewerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Viewer id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 20
+	}
+
+	// The total is counted while scanning for the first page, later pages don't repeat it
+	fps := make(map[string]datastruct.FavoriteParty)
+	fIds, p, count, err := s.scanFriends(ctx, req.ViewerId, p, limit, req.NextPage == "", func(fIds []string) ([]string, error) {
+		res, err := s.fp.GetFavorisingUsersIn(ctx, req.PartyId, fIds)
+		var matched []string
+		for _, fp := range res {
+			fps[fp.UserId] = fp
+			matched = append(matched, fp.UserId)
+		}
+		return matched, err
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	var res []*rg.FavoriteParty
+	for _, fId := range fIds {
+		res = append(res, fps[fId].ToGRPCFavoriteParty())
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	return &rg.PagedFriendsWhoFavoritedParty{FavoriteParties: res, Count: uint32(count), NextPage: nextPage}, nil
+}
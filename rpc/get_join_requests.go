@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetJoinRequests(ctx context.Context, req *rg.GetJoinRequestsRequest) (*rg.PagedPartyJoinRequests, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	_, err = ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	_, err = s.checkHost(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	js, p, err := s.pp.GetJoinRequests(ctx, repository.GetJoinRequestsParams{
+		PId:   req.PartyId,
+		Page:  p,
+		Limit: int(req.Limit),
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	var res []*rg.PartyJoinRequest
+	for _, j := range js {
+		res = append(res, j.ToGRPCPartyJoinRequest())
+	}
+
+	return &rg.PagedPartyJoinRequests{JoinRequests: res, NextPage: nextPage}, nil
+}
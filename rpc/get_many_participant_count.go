@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+)
+
+func (s relationServer) GetManyParticipantCount(ctx context.Context, req *rg.GetManyParticipantCountRequest) (*rg.GetManyParticipantCountResponse, error) {
+	pcs, err := s.pp.GetManyParticipantCount(ctx, req.PartyIds)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	pcMap := make(map[string]uint32, len(pcs))
+	gcMap := make(map[string]uint32, len(pcs))
+	for _, pc := range pcs {
+		pcMap[pc.PartyId] = uint32(pc.ParticipantCount)
+		gcMap[pc.PartyId] = uint32(pc.GuestCount)
+	}
+
+	return &rg.GetManyParticipantCountResponse{ParticipantCounts: pcMap, GuestCounts: gcMap}, nil
+}
@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetParticipantCount(ctx context.Context, req *rg.GetParticipantCountRequest) (*rg.GetParticipantCountResponse, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	pc, err := s.pp.GetParticipantCount(ctx, req.PartyId)
+	if err != nil {
+		return &rg.GetParticipantCountResponse{ParticipantCount: 0}, utils.HandleError(err)
+	}
+
+	return &rg.GetParticipantCountResponse{
+		ParticipantCount: uint32(pc.ParticipantCount),
+		GuestCount:       uint32(pc.GuestCount),
+		CheckedInCount:   uint32(pc.CheckedInCount),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetRsvp(ctx context.Context, req *rg.GetRsvpRequest) (*rg.PartyRsvp, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	r, err := s.pr.GetRsvp(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return r.ToGRPCPartyRsvp(), nil
+}
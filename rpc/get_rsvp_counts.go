@@ -0,0 +1,38 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetRsvpCounts(ctx context.Context, req *rg.GetRsvpCountsRequest) (*rg.GetRsvpCountsResponse, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	rcs, err := s.pr.GetRsvpCounts(ctx, req.PartyId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	res := &rg.GetRsvpCountsResponse{}
+	for _, rc := range rcs {
+		switch rc.Status {
+		case datastruct.RSVP_GOING:
+			res.Going = uint32(rc.RsvpCount)
+		case datastruct.RSVP_INTERESTED:
+			res.Interested = uint32(rc.RsvpCount)
+		case datastruct.RSVP_NOT_GOING:
+			res.NotGoing = uint32(rc.RsvpCount)
+		}
+	}
+
+	return res, nil
+}
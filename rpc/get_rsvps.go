@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetRsvps(ctx context.Context, req *rg.GetRsvpsRequest) (*rg.PagedPartyRsvps, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	st := datastruct.RsvpStatusFromGRPC(req.Status)
+	if st == "" {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Rsvp Status")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	rs, p, err := s.pr.GetRsvpsByStatus(ctx, req.PartyId, st, p, req.Limit)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	var res []*rg.PartyRsvp
+	for _, r := range rs {
+		res = append(res, r.ToGRPCPartyRsvp())
+	}
+
+	return &rg.PagedPartyRsvps{Rsvps: res, NextPage: nextPage}, nil
+}
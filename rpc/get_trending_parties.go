@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTrendingWindowHours = 24
+	maxTrendingWindowHours     = int(repository.MaxHourlyFavoriteWindow / time.Hour)
+	// Favorites lose half of their weight every 6 hours
+	trendingHalfLifeHours = 6.0
+)
+
+func (s relationServer) GetTrendingParties(ctx context.Context, req *rg.GetTrendingPartiesRequest) (*rg.TrendingParties, error) {
+	window := int(req.WindowHours)
+	if window == 0 {
+		window = defaultTrendingWindowHours
+	}
+	if window > maxTrendingWindowHours {
+		return nil, status.Errorf(codes.InvalidArgument, "Window can't be longer than %d hours", maxTrendingWindowHours)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 20
+	}
+
+	now := time.Now()
+	scores := make(map[string]float64)
+	for age := 0; age < window; age++ {
+		cs, err := s.fp.GetHourlyFavoriteCounts(ctx, now.Add(-time.Duration(age)*time.Hour))
+		if err != nil {
+			return nil, utils.HandleError(err)
+		}
+
+		decay := math.Pow(0.5, float64(age)/trendingHalfLifeHours)
+		for _, c := range cs {
+			scores[c.PartyId] += float64(c.FavoriteCount) * decay
+		}
+	}
+
+	var res []*rg.TrendingParty
+	for pId, score := range scores {
+		if score <= 0 {
+			continue
+		}
+		res = append(res, &rg.TrendingParty{PartyId: pId, Score: score})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Score > res[j].Score
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return &rg.TrendingParties{Parties: res}, nil
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetUserInvites(ctx context.Context, req *rg.GetUserInvitesRequest) (*rg.PagedPartyInvites, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	is, p, err := s.pp.GetUserInvites(ctx, repository.GetUserInvitesParams{
+		UId:   req.UserId,
+		Page:  p,
+		Limit: int(req.Limit),
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	var res []*rg.PartyInvite
+	for _, i := range is {
+		res = append(res, i.ToGRPCPartyInvite())
+	}
+
+	return &rg.PagedPartyInvites{Invites: res, NextPage: nextPage}, nil
+}
@@ -0,0 +1,42 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) GetUserParticipations(ctx context.Context, req *rg.GetUserParticipationsRequest) (*rg.PagedPartyParticipants, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	p, err := base64.URLEncoding.DecodeString(req.NextPage)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Next Page Param")
+	}
+
+	ps, p, err := s.pp.GetUserParticipations(ctx, repository.GetUserParticipationsParams{
+		UId:   req.UserId,
+		Page:  p,
+		Limit: int(req.Limit),
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	nextPage := base64.URLEncoding.EncodeToString(p)
+
+	var res []*rg.PartyParticipant
+	for _, pp := range ps {
+		res = append(res, pp.ToGRPCPartyParticipant())
+	}
+
+	return &rg.PagedPartyParticipants{Participants: res, NextPage: nextPage}, nil
+}
@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const partyInviteValidFor = 7 * 24 * time.Hour
+
+func (s relationServer) InviteToParty(ctx context.Context, req *rg.InviteToPartyRequest) (*rg.PartyInvites, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	_, err = ksuid.Parse(req.InviterId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Inviter id")
+	}
+
+	err = s.checkCanInvite(ctx, req.PartyId, req.InviterId)
+	if err != nil {
+		return nil, err
+	}
+
+	var uIds []string
+	if req.GroupId != "" {
+		_, err = ksuid.Parse(req.GroupId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid Group id")
+		}
+
+		uIds, err = s.getGroupFriendIds(ctx, req.InviterId, req.GroupId)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		_, err = ksuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+		}
+		uIds = []string{req.UserId}
+	}
+
+	var res []*rg.PartyInvite
+	for _, uId := range uIds {
+		err = s.checkNotBanned(ctx, req.PartyId, uId)
+		// Banned group members are skipped instead of failing the whole group
+		if status.Code(err) == codes.PermissionDenied && req.GroupId != "" {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		i, err := s.pp.Invite(ctx, repository.InviteParams{
+			UserId:    uId,
+			InviterId: req.InviterId,
+			PartyId:   req.PartyId,
+			ValidFor:  partyInviteValidFor,
+		})
+		if err != nil {
+			return nil, utils.HandleError(err)
+		}
+		res = append(res, i.ToGRPCPartyInvite())
+	}
+
+	return &rg.PartyInvites{Invites: res}, nil
+}
+
+// Hosts can always invite, guests only if the party allows guest invites
+func (s relationServer) checkCanInvite(ctx context.Context, pId, uId string) error {
+	r, err := s.getRole(ctx, pId, uId)
+	if err != nil {
+		return utils.HandleError(err)
+	}
+	if r.IsHost() {
+		return nil
+	}
+
+	ps, err := s.ps.GetPartySettings(ctx, pId)
+	if err != nil {
+		return utils.HandleError(err)
+	}
+	if !ps.AllowGuestInvites {
+		return status.Error(codes.PermissionDenied, "Only Hosts can invite to this Party")
+	}
+
+	ok, err := s.pp.IsParticipant(ctx, repository.UserPartyParams{
+		UserId:  uId,
+		PartyId: pId,
+	})
+	if err != nil {
+		return utils.HandleError(err)
+	}
+	if !ok {
+		return status.Error(codes.PermissionDenied, "Not a participant of this Party")
+	}
+
+	return nil
+}
+
+// Returns all members of the group which are still friends of its owner
+func (s relationServer) getGroupFriendIds(ctx context.Context, uId, gId string) ([]string, error) {
+	_, err := s.fg.GetGroup(ctx, uId, gId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Group not found")
+	}
+
+	var res []string
+	var p []byte
+	for {
+		ms, next, err := s.fg.GetGroupMembers(ctx, gId, p, 100)
+		if err != nil {
+			return nil, utils.HandleError(err)
+		}
+
+		for _, m := range ms {
+			fr, err := s.fs.GetFriendRelation(ctx, uId, m.UserId)
+			if err != nil && status.Code(err) != codes.NotFound {
+				return nil, utils.HandleError(err)
+			}
+			if err != nil || !fr.Accepted {
+				continue
+			}
+			res = append(res, m.UserId)
+		}
+
+		if len(next) == 0 {
+			return res, nil
+		}
+		p = next
+	}
+}
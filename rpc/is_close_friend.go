@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) IsCloseFriend(ctx context.Context, req *rg.IsCloseFriendRequest) (*rg.IsCloseFriendResponse, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.FriendId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Friend id")
+	}
+
+	ok, err := s.cf.IsCloseFriend(ctx, req.UserId, req.FriendId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return &rg.IsCloseFriendResponse{IsCloseFriend: ok}, nil
+}
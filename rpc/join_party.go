@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"log"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) JoinParty(ctx context.Context, req *rg.JoinPartyRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	err = s.checkNotBanned(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := s.ps.GetPartySettings(ctx, req.PartyId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+	if ps.Private {
+		return nil, status.Error(codes.PermissionDenied, "Party is private, request to join instead")
+	}
+
+	err = s.reserveSeats(ctx, req.PartyId, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pp.Join(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		s.releaseSeats(ctx, req.PartyId, 1)
+		return nil, utils.HandleError(err)
+	}
+
+	s.stream.PublishEvent(&events.PartyJoined{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
+
+// Atomically takes seats for a participant or his plus-ones, returns ResourceExhausted if not enough are left
+func (s relationServer) reserveSeats(ctx context.Context, pId string, seats int) error {
+	err := s.ps.ReserveSeats(ctx, pId, seats)
+	if err != nil {
+		return utils.HandleError(err)
+	}
+
+	return nil
+}
+
+// Failures are only logged, the participant change itself already happened
+func (s relationServer) releaseSeats(ctx context.Context, pId string, seats int) {
+	err := s.ps.ReleaseSeats(ctx, pId, seats)
+	if err != nil {
+		log.Println("Error releasing Seats: ", err)
+	}
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) JoinWaitlist(ctx context.Context, req *rg.JoinWaitlistRequest) (*rg.WaitlistEntry, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	err = s.checkNotBanned(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only full parties have a waitlist
+	err = s.checkCapacity(ctx, req.PartyId)
+	if err == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Party isn't full")
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		return nil, err
+	}
+
+	w, err := s.pp.JoinWaitlist(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return w.ToGRPCWaitlistEntry(), nil
+}
+
+// Returns ResourceExhausted if all seats of the party are taken. Joins reserve their seat atomically instead,
+// this only tells whether the waitlist is open, so reading the seats taken is good enough.
+func (s relationServer) checkCapacity(ctx context.Context, pId string) error {
+	ps, err := s.ps.GetPartySettings(ctx, pId)
+	if err != nil {
+		return utils.HandleError(err)
+	}
+	if ps.Capacity > 0 && ps.TakenSeats >= ps.Capacity {
+		return status.Error(codes.ResourceExhausted, "Party is full")
+	}
+
+	return nil
+}
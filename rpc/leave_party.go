@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"context"
+	"log"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) LeaveParty(ctx context.Context, req *rg.LeavePartyRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	p, err := s.pp.Leave(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	s.releaseSeats(ctx, p.PartyId, 1+p.PlusOnes)
+	s.publishLeft(p)
+	s.promoteFromWaitlist(ctx, req.PartyId, 1+p.PlusOnes)
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
+
+// The plus-ones and the check-in of the participant leave together with him
+func (s relationServer) publishLeft(p datastruct.PartyParticipant) {
+	s.stream.PublishEvent(&events.PartyLeft{
+		UserId:  p.UserId,
+		PartyId: p.PartyId,
+	})
+
+	if p.PlusOnes > 0 {
+		s.stream.PublishEvent(&events.PartyPlusOnesChanged{
+			UserId:  p.UserId,
+			PartyId: p.PartyId,
+			Delta:   -int32(p.PlusOnes),
+		})
+	}
+
+	if !p.CheckedInAt.IsZero() {
+		s.stream.PublishEvent(&events.PartyCheckInRemoved{
+			UserId:  p.UserId,
+			PartyId: p.PartyId,
+		})
+	}
+}
+
+// Moves the longest waiting users into the party until the freed seats are taken again
+func (s relationServer) promoteFromWaitlist(ctx context.Context, pId string, seats int) {
+	ps, err := s.ps.GetPartySettings(ctx, pId)
+	if err != nil || ps.Capacity == 0 {
+		return
+	}
+
+	for i := 0; i < seats; i++ {
+		if !s.promoteNext(ctx, pId) {
+			return
+		}
+	}
+}
+
+// Returns false if nobody could be promoted, because the party is full again or nobody is waiting
+func (s relationServer) promoteNext(ctx context.Context, pId string) bool {
+	// The seat is taken before popping so a concurrent join can't leave the promoted user without one
+	err := s.ps.ReserveSeats(ctx, pId, 1)
+	if err != nil {
+		return false
+	}
+
+	w, err := s.pp.PopWaitlist(ctx, pId)
+	if err != nil {
+		s.releaseSeats(ctx, pId, 1)
+		return false
+	}
+
+	err = s.pp.Join(ctx, repository.UserPartyParams{
+		UserId:  w.UserId,
+		PartyId: pId,
+	})
+	if err != nil {
+		s.releaseSeats(ctx, pId, 1)
+		log.Println("Error promoting from Waitlist: ", err)
+		return false
+	}
+
+	s.stream.PublishEvent(&events.PartyJoined{
+		UserId:  w.UserId,
+		PartyId: pId,
+	})
+	s.stream.PublishEvent(&events.WaitlistPromoted{
+		UserId:  w.UserId,
+		PartyId: pId,
+	})
+
+	return true
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxFriendTokenRedemptions   = 20
+	friendTokenRedemptionWindow = time.Hour
+)
+
+func (s relationServer) RedeemFriendToken(ctx context.Context, req *rg.RedeemFriendTokenRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+
+	oId, expiresAt, err := s.parseFriendToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Before(time.Now()) {
+		return nil, status.Error(codes.FailedPrecondition, "Token expired")
+	}
+	if oId == req.UserId {
+		return nil, status.Error(codes.InvalidArgument, "Can't add yourself as a friend")
+	}
+
+	blocked, err := s.bl.IsBlocked(ctx, req.UserId, oId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+	if blocked {
+		return nil, status.Error(codes.PermissionDenied, "Can't add this user as a friend")
+	}
+
+	n, err := s.fs.CountTokenRedemptions(ctx, req.UserId, time.Now().Add(-friendTokenRedemptionWindow))
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+	if n >= maxFriendTokenRedemptions {
+		return nil, status.Error(codes.ResourceExhausted, "Too many friends added, try again later")
+	}
+
+	// The token owner agreed to the friendship by sharing the token, so pending requests
+	// in either direction are accepted and otherwise a request is created and accepted
+	fr, err := s.fs.GetFriendRelation(ctx, req.UserId, oId)
+	switch {
+	case status.Code(err) == codes.NotFound:
+		err = s.fs.CreateFriendRequest(ctx, oId, req.UserId, "", "")
+		if err != nil {
+			return nil, utils.HandleError(err)
+		}
+		err = s.fs.AcceptFriendRequest(ctx, req.UserId, oId)
+	case err != nil:
+		return nil, utils.HandleError(err)
+	case fr.Accepted:
+		return nil, status.Error(codes.AlreadyExists, "Already friends")
+	default:
+		err = s.fs.AcceptFriendRequest(ctx, fr.UserId, fr.FriendId)
+	}
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	err = s.fs.AddTokenRedemption(ctx, req.UserId, oId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
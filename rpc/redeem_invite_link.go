@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) RedeemInviteLink(ctx context.Context, req *rg.RedeemInviteLinkRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	lId, err := s.tk.Verify(req.Token)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Token")
+	}
+
+	l, err := s.il.GetInviteLink(ctx, lId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	params := repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: l.PartyId,
+	}
+
+	err = s.checkNotBanned(ctx, l.PartyId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Checked upfront so joined users don't use up the link
+	ok, err := s.pp.IsParticipant(ctx, params)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+	if ok {
+		return nil, status.Error(codes.AlreadyExists, "Already joined the Party")
+	}
+
+	err = s.reserveSeats(ctx, l.PartyId, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.il.UseInviteLink(ctx, lId)
+	if err != nil {
+		s.releaseSeats(ctx, l.PartyId, 1)
+		return nil, utils.HandleError(err)
+	}
+
+	err = s.pp.Join(ctx, params)
+	if err != nil {
+		s.releaseSeats(ctx, l.PartyId, 1)
+		return nil, utils.HandleError(err)
+	}
+
+	s.stream.PublishEvent(&events.PartyJoined{
+		UserId:  req.UserId,
+		PartyId: l.PartyId,
+	})
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
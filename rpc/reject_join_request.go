@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) RejectJoinRequest(ctx context.Context, req *rg.RejectJoinRequestRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.ApproverId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Approver id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	_, err = s.checkHost(ctx, req.PartyId, req.ApproverId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.pp.RejectJoinRequest(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	s.stream.PublishEvent(&events.PartyJoinRequestRejected{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
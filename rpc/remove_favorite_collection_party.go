@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Removing a party from a collection keeps it favorited
+func (s relationServer) RemoveFavoriteCollectionParty(ctx context.Context, req *rg.FavoriteCollectionPartyRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.CollectionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Collection id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	_, err = s.fc.GetCollection(ctx, req.UserId, req.CollectionId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Collection not found")
+	}
+
+	err = s.fc.RemoveCollectionParty(ctx, req.CollectionId, req.PartyId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) RemoveFriendGroupMember(ctx context.Context, req *rg.RemoveFriendGroupMemberRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.GroupId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Group id")
+	}
+	_, err = ksuid.Parse(req.MemberId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Member id")
+	}
+
+	_, err = s.fg.GetGroup(ctx, req.UserId, req.GroupId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Group not found")
+	}
+
+	err = s.fg.RemoveGroupMember(ctx, req.GroupId, req.MemberId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
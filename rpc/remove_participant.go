@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) RemoveParticipant(ctx context.Context, req *rg.RemoveParticipantRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.RemoverId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Remover id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	r, err := s.checkHost(ctx, req.PartyId, req.RemoverId)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkRemovable(ctx, r, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Revoked first so a removed Co-Host can't keep managing the party if anything fails afterwards
+	err = s.ro.RevokeRole(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	p, err := s.pp.Leave(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	s.afterRemoval(ctx, p)
+
+	s.stream.PublishEvent(&events.PartyParticipantRemoved{
+		UserId:    req.UserId,
+		PartyId:   req.PartyId,
+		RemoverId: req.RemoverId,
+	})
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
+
+// The Host can't be removed and Co-Hosts can only be removed by the Host
+func (s relationServer) checkRemovable(ctx context.Context, remover datastruct.PartyRole, uId string) error {
+	if remover.UserId == uId {
+		return status.Error(codes.InvalidArgument, "Can't remove yourself, leave the Party instead")
+	}
+
+	r, err := s.ro.GetRole(ctx, remover.PartyId, uId)
+	if err != nil {
+		return utils.HandleError(err)
+	}
+	if r.Role == datastruct.ROLE_HOST || (r.Role == datastruct.ROLE_CO_HOST && remover.Role != datastruct.ROLE_HOST) {
+		return status.Error(codes.PermissionDenied, "Not allowed to remove this User")
+	}
+
+	return nil
+}
+
+// Cleans up after a participant was removed from the party
+func (s relationServer) afterRemoval(ctx context.Context, p datastruct.PartyParticipant) {
+	s.releaseSeats(ctx, p.PartyId, 1+p.PlusOnes)
+	s.publishLeft(p)
+	s.promoteFromWaitlist(ctx, p.PartyId, 1+p.PlusOnes)
+}
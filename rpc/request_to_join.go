@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) RequestToJoin(ctx context.Context, req *rg.RequestToJoinRequest) (*rg.PartyJoinRequest, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+
+	err = s.checkNotBanned(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := s.ps.GetPartySettings(ctx, req.PartyId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+	if !ps.Private {
+		return nil, status.Error(codes.FailedPrecondition, "Party is public, join it directly")
+	}
+
+	j, err := s.pp.RequestToJoin(ctx, repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	s.stream.PublishEvent(&events.PartyJoinRequested{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	})
+
+	return j.ToGRPCPartyJoinRequest(), nil
+}
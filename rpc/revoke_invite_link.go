@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) RevokeInviteLink(ctx context.Context, req *rg.RevokeInviteLinkRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	lId, err := s.tk.Verify(req.Token)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Token")
+	}
+
+	l, err := s.il.GetInviteLink(ctx, lId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	// Links can be revoked by their creator and by the hosts of the party
+	if l.CreatorId != req.UserId {
+		_, err = s.checkHost(ctx, l.PartyId, req.UserId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.il.RevokeInviteLink(ctx, lId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	cg "github.com/clubo-app/protobuf/common"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) RevokeRole(ctx context.Context, req *rg.RevokeRoleRequest) (*cg.SuccessIndicator, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.RevokerId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Revoker id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	if req.UserId == req.RevokerId {
+		return nil, status.Error(codes.InvalidArgument, "Can't revoke your own role")
+	}
+
+	r, err := s.checkHost(ctx, req.PartyId, req.RevokerId)
+	if err != nil {
+		return nil, err
+	}
+	if r.Role != datastruct.ROLE_HOST {
+		return nil, status.Error(codes.PermissionDenied, "Only the Host can revoke roles")
+	}
+
+	err = s.ro.RevokeRole(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return &cg.SuccessIndicator{Sucess: true}, nil
+}
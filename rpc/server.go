@@ -6,22 +6,64 @@ import (
 	"strings"
 
 	"github.com/clubo-app/packages/stream"
+	pg "github.com/clubo-app/protobuf/party"
 	rg "github.com/clubo-app/protobuf/relation"
 	"github.com/clubo-app/relation-service/service"
+	"github.com/clubo-app/relation-service/token"
 	"google.golang.org/grpc"
 )
 
 type relationServer struct {
 	fs     service.FriendRelationService
+	cf     service.CloseFriendService
+	bl     service.BlockService
+	fg     service.FriendGroupService
+	fa     service.FriendActivityService
 	fp     service.FavoriteParty
+	fc     service.FavoriteCollectionService
+	pp     service.PartyParticipantsService
+	ps     service.PartySettingsService
+	pr     service.PartyRsvpService
+	ro     service.PartyRoleService
+	il     service.PartyInviteLinkService
+	tk     token.Signer
+	pc     pg.PartyServiceClient
 	stream stream.Stream
 	rg.UnimplementedRelationServiceServer
 }
 
-func NewRelationServer(fs service.FriendRelationService, fp service.FavoriteParty, stream stream.Stream) rg.RelationServiceServer {
+func NewRelationServer(
+	fs service.FriendRelationService,
+	cf service.CloseFriendService,
+	bl service.BlockService,
+	fg service.FriendGroupService,
+	fa service.FriendActivityService,
+	fp service.FavoriteParty,
+	fc service.FavoriteCollectionService,
+	pp service.PartyParticipantsService,
+	ps service.PartySettingsService,
+	pr service.PartyRsvpService,
+	ro service.PartyRoleService,
+	il service.PartyInviteLinkService,
+	tk token.Signer,
+	pc pg.PartyServiceClient,
+	stream stream.Stream,
+) rg.RelationServiceServer {
 	return &relationServer{
 		fs:     fs,
+		cf:     cf,
+		bl:     bl,
+		fg:     fg,
+		fa:     fa,
 		fp:     fp,
+		fc:     fc,
+		pp:     pp,
+		ps:     ps,
+		pr:     pr,
+		ro:     ro,
+		il:     il,
+		tk:     tk,
+		pc:     pc,
 		stream: stream,
 	}
 }
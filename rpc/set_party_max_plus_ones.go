@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) SetPartyMaxPlusOnes(ctx context.Context, req *rg.SetPartyMaxPlusOnesRequest) (*rg.PartySettings, error) {
+	_, err := ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	_, err = ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+
+	_, err = s.checkHost(ctx, req.PartyId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := s.ps.SetMaxPlusOnes(ctx, req.PartyId, int(req.MaxPlusOnes))
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return ps.ToGRPCPartySettings(), nil
+}
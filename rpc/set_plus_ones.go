@@ -0,0 +1,89 @@
+package rpc
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/clubo-app/packages/utils"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) SetPlusOnes(ctx context.Context, req *rg.SetPlusOnesRequest) (*rg.PartyParticipant, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	if len(req.GuestNames) > int(req.PlusOnes) {
+		return nil, status.Error(codes.InvalidArgument, "More Guest names than Plus-Ones")
+	}
+	for _, n := range req.GuestNames {
+		if utf8.RuneCountInString(n) > 50 {
+			return nil, status.Error(codes.InvalidArgument, "Guest names can't be longer than 50 characters")
+		}
+	}
+
+	ps, err := s.ps.GetPartySettings(ctx, req.PartyId)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+	if int(req.PlusOnes) > ps.MaxPlusOnes {
+		return nil, status.Errorf(codes.FailedPrecondition, "Party allows at most %d Plus-Ones", ps.MaxPlusOnes)
+	}
+
+	params := repository.UserPartyParams{
+		UserId:  req.UserId,
+		PartyId: req.PartyId,
+	}
+
+	p, err := s.pp.GetParticipant(ctx, params)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	// Additional guests need free seats, fewer guests are always fine
+	delta := int(req.PlusOnes) - p.PlusOnes
+	if delta > 0 {
+		err = s.reserveSeats(ctx, req.PartyId, delta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = s.pp.SetPlusOnes(ctx, repository.SetPlusOnesParams{
+		UserId:     req.UserId,
+		PartyId:    req.PartyId,
+		PlusOnes:   int(req.PlusOnes),
+		GuestNames: req.GuestNames,
+	})
+	if err != nil {
+		if delta > 0 {
+			s.releaseSeats(ctx, req.PartyId, delta)
+		}
+		return nil, utils.HandleError(err)
+	}
+	if delta < 0 {
+		s.releaseSeats(ctx, req.PartyId, -delta)
+	}
+
+	if delta != 0 {
+		s.stream.PublishEvent(&events.PartyPlusOnesChanged{
+			UserId:  req.UserId,
+			PartyId: req.PartyId,
+			Delta:   int32(delta),
+		})
+	}
+
+	p.PlusOnes = int(req.PlusOnes)
+	p.GuestNames = req.GuestNames
+
+	return p.ToGRPCPartyParticipant(), nil
+}
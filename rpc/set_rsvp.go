@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	"github.com/clubo-app/protobuf/events"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) SetRsvp(ctx context.Context, req *rg.SetRsvpRequest) (*rg.PartyRsvp, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.PartyId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Party id")
+	}
+	st := datastruct.RsvpStatusFromGRPC(req.Status)
+	if st == "" {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Rsvp Status")
+	}
+
+	r, prev, err := s.pr.SetRsvp(ctx, req.PartyId, req.UserId, st)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	if prev != st {
+		s.stream.PublishEvent(&events.PartyRsvpChanged{
+			UserId:         req.UserId,
+			PartyId:        req.PartyId,
+			Status:         st,
+			PreviousStatus: prev,
+		})
+	}
+
+	return r.ToGRPCPartyRsvp(), nil
+}
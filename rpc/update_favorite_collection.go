@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) UpdateFavoriteCollection(ctx context.Context, req *rg.UpdateFavoriteCollectionRequest) (*rg.FavoriteCollection, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.CollectionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Collection id")
+	}
+
+	c, err := s.fc.RenameCollection(ctx, req.UserId, req.CollectionId, req.Name)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return c.ToGRPCFavoriteCollection(), nil
+}
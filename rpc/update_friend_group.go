@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/clubo-app/packages/utils"
+	rg "github.com/clubo-app/protobuf/relation"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s relationServer) UpdateFriendGroup(ctx context.Context, req *rg.UpdateFriendGroupRequest) (*rg.FriendGroup, error) {
+	_, err := ksuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid User id")
+	}
+	_, err = ksuid.Parse(req.GroupId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid Group id")
+	}
+
+	g, err := s.fg.RenameGroup(ctx, req.UserId, req.GroupId, req.Name)
+	if err != nil {
+		return nil, utils.HandleError(err)
+	}
+
+	return g.ToGRPCFriendGroup(), nil
+}
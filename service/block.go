@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+)
+
+type BlockService interface {
+	Block(ctx context.Context, uId, bId string) (datastruct.Block, error)
+	Unblock(ctx context.Context, uId, bId string) error
+	GetBlocks(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.Block, []byte, error)
+	IsBlocked(ctx context.Context, uId, oId string) (bool, error)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+)
+
+type CloseFriendService interface {
+	AddCloseFriend(ctx context.Context, uId, fId string) (datastruct.CloseFriend, error)
+	RemoveCloseFriend(ctx context.Context, uId, fId string) error
+	GetCloseFriends(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.CloseFriend, []byte, error)
+	IsCloseFriend(ctx context.Context, uId, fId string) (bool, error)
+}
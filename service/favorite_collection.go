@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+)
+
+type FavoriteCollectionService interface {
+	CreateCollection(ctx context.Context, uId, name string) (datastruct.FavoriteCollection, error)
+	RenameCollection(ctx context.Context, uId, cId, name string) (datastruct.FavoriteCollection, error)
+	DeleteCollection(ctx context.Context, uId, cId string) error
+	GetCollection(ctx context.Context, uId, cId string) (datastruct.FavoriteCollection, error)
+	GetCollections(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FavoriteCollection, []byte, error)
+	AddCollectionParty(ctx context.Context, uId, cId, pId string) (datastruct.FavoriteCollectionParty, error)
+	RemoveCollectionParty(ctx context.Context, cId, pId string) error
+	RemovePartyFromCollections(ctx context.Context, uId, pId string) error
+	GetCollectionParties(ctx context.Context, cId string, page []byte, limit uint64) ([]datastruct.FavoriteCollectionParty, []byte, error)
+	GetPartyCollections(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.FavoriteCollectionParty, []byte, error)
+	RemoveCollectionParties(ctx context.Context, pId string, cIds []string) error
+}
@@ -2,17 +2,26 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/clubo-app/relation-service/datastruct"
 )
 
 type FavoriteParty interface {
 	FavorParty(ctx context.Context, fp datastruct.FavoriteParty) (datastruct.FavoriteParty, error)
-	DefavorParty(ctx context.Context, uId, pId string) error
+	DefavorParty(ctx context.Context, uId, pId string) (datastruct.FavoriteParty, error)
 	GetFavoritePartiesByUser(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FavoriteParty, []byte, error)
 	GetFavorisingUsersByParty(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.FavoriteParty, []byte, error)
+	GetFavorisingUsersIn(ctx context.Context, pId string, uIds []string) ([]datastruct.FavoriteParty, error)
+	GetFavoritesIn(ctx context.Context, uId string, pIds []string) ([]datastruct.FavoriteParty, error)
 	GetfavoritePartyCount(ctx context.Context, pId string) (datastruct.FavoritePartyCount, error)
 	GetManyfavoritePartyCount(ctx context.Context, pIds []string) ([]datastruct.FavoritePartyCount, error)
 	IncreaseFavoritePartyCount(ctx context.Context, pId string) error
 	DecreaseFavoritePartyCount(ctx context.Context, pId string) error
+	IncreaseHourlyFavoriteCount(ctx context.Context, pId string) error
+	DecreaseHourlyFavoriteCount(ctx context.Context, pId string, favoritedAt time.Time) error
+	GetHourlyFavoriteCounts(ctx context.Context, hour time.Time) ([]datastruct.HourlyFavoriteCount, error)
+	DeleteHourlyFavoriteCounts(ctx context.Context, hour time.Time) error
+	DeleteFavoriteCounts(ctx context.Context, pId string) error
+	RemoveFavorites(ctx context.Context, pId string, uIds []string) error
 }
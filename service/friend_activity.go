@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+)
+
+type FriendActivityService interface {
+	FanOut(ctx context.Context, uIds []string, actorId, pId, activityType string) error
+	GetFeed(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendActivity, []byte, error)
+	GetPartyActivities(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.FriendActivity, []byte, error)
+	RemoveActivities(ctx context.Context, as []datastruct.FriendActivity) error
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+)
+
+type FriendGroupService interface {
+	CreateGroup(ctx context.Context, uId, name string) (datastruct.FriendGroup, error)
+	RenameGroup(ctx context.Context, uId, gId, name string) (datastruct.FriendGroup, error)
+	DeleteGroup(ctx context.Context, uId, gId string) error
+	GetGroup(ctx context.Context, uId, gId string) (datastruct.FriendGroup, error)
+	GetGroups(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendGroup, []byte, error)
+	AddGroupMember(ctx context.Context, gId, mId string) (datastruct.FriendGroupMember, error)
+	RemoveGroupMember(ctx context.Context, gId, mId string) error
+	GetGroupMembers(ctx context.Context, gId string, page []byte, limit uint64) ([]datastruct.FriendGroupMember, []byte, error)
+}
@@ -2,20 +2,28 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/clubo-app/relation-service/datastruct"
 )
 
 type FriendRelationService interface {
-	CreateFriendRequest(ctx context.Context, uId, fId string) error
+	CreateFriendRequest(ctx context.Context, uId, fId, message, sourcePartyId string) error
 	DeclineFriendRequest(ctx context.Context, uId, fId string) error
 	AcceptFriendRequest(ctx context.Context, uId, fId string) error
 	RemoveFriendRelation(ctx context.Context, uId, fId string) error
 	GetFriendRelation(ctx context.Context, uId, fId string) (datastruct.FriendRelation, error)
 	GetFriends(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendRelation, []byte, error)
+	GetFriendsByAcceptedAt(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendRelation, []byte, error)
+	GetFriendsByDisplayName(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendRelation, []byte, error)
+	UpdateDisplayName(ctx context.Context, uId, displayName string) error
 	GetIncomingFriendRequests(ctx context.Context, uId string, page []byte, limit uint64) ([]datastruct.FriendRelation, []byte, error)
 	IncreaseFriendCount(ctx context.Context, uId string) error
 	DecreaseFriendCount(ctx context.Context, uId string) error
 	GetFriendCount(ctx context.Context, uId string) (datastruct.FriendCount, error)
 	GetManyFriendCount(ctx context.Context, ids []string) ([]datastruct.FriendCount, error)
+	GetExpiredFriendRequests(ctx context.Context, day time.Time, until time.Time) ([]datastruct.FriendRequestExpiry, error)
+	ExpireFriendRequest(ctx context.Context, e datastruct.FriendRequestExpiry) (bool, error)
+	AddTokenRedemption(ctx context.Context, uId, fId string) error
+	CountTokenRedemptions(ctx context.Context, uId string, since time.Time) (int, error)
 }
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/clubo-app/relation-service/repository"
+)
+
+type PartyInviteLinkService interface {
+	CreateInviteLink(context.Context, repository.CreateInviteLinkParams) (datastruct.PartyInviteLink, error)
+	GetInviteLink(ctx context.Context, lId string) (datastruct.PartyInviteLink, error)
+	UseInviteLink(ctx context.Context, lId string) (datastruct.PartyInviteLink, error)
+	RevokeInviteLink(ctx context.Context, lId string) error
+	GetPartyInviteLinks(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.PartyInviteLink, []byte, error)
+	DeleteInviteLinks(ctx context.Context, lIds []string) error
+}
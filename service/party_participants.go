@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/clubo-app/relation-service/datastruct"
+	"github.com/clubo-app/relation-service/repository"
+)
+
+type PartyParticipantsService interface {
+	Invite(context.Context, repository.InviteParams) (datastruct.PartyInvite, error)
+	BulkInvite(context.Context, repository.BulkInviteParams) ([]datastruct.PartyInvite, error)
+	GetInvite(context.Context, repository.UserPartyParams) (datastruct.PartyInvite, error)
+	Decline(context.Context, repository.UserPartyParams) error
+	Accept(context.Context, repository.UserPartyParams) error
+	GetUserInvites(context.Context, repository.GetUserInvitesParams) ([]datastruct.PartyInvite, []byte, error)
+	Join(context.Context, repository.UserPartyParams) error
+	Leave(context.Context, repository.UserPartyParams) (datastruct.PartyParticipant, error)
+	GetPartyParticipants(context.Context, repository.GetPartyParticipantsParams) ([]datastruct.PartyParticipant, []byte, error)
+	GetParticipant(context.Context, repository.UserPartyParams) (datastruct.PartyParticipant, error)
+	IsParticipant(context.Context, repository.UserPartyParams) (bool, error)
+	GetParticipantsIn(ctx context.Context, pId string, uIds []string) ([]datastruct.PartyParticipant, error)
+	SetPlusOnes(context.Context, repository.SetPlusOnesParams) (datastruct.PartyParticipant, error)
+	CheckIn(context.Context, repository.CheckInParams) (datastruct.PartyCheckIn, error)
+	GetCheckedIn(context.Context, repository.GetCheckedInParams) ([]datastruct.PartyCheckIn, []byte, error)
+	GetUserParticipations(context.Context, repository.GetUserParticipationsParams) ([]datastruct.PartyParticipant, []byte, error)
+	GetExpiredInvites(ctx context.Context, day time.Time, until time.Time) ([]datastruct.PartyInviteExpiry, error)
+	ExpireInvite(context.Context, datastruct.PartyInviteExpiry) (bool, error)
+	IncreaseParticipantCount(ctx context.Context, pId string) error
+	DecreaseParticipantCount(ctx context.Context, pId string) error
+	ChangeGuestCount(ctx context.Context, pId string, delta int) error
+	IncreaseCheckedInCount(ctx context.Context, pId string) error
+	DecreaseCheckedInCount(ctx context.Context, pId string) error
+	GetParticipantCount(ctx context.Context, pId string) (datastruct.PartyParticipantCount, error)
+	GetManyParticipantCount(ctx context.Context, pIds []string) ([]datastruct.PartyParticipantCount, error)
+	JoinWaitlist(context.Context, repository.UserPartyParams) (datastruct.WaitlistEntry, error)
+	LeaveWaitlist(context.Context, repository.UserPartyParams) error
+	GetWaitlist(context.Context, repository.GetWaitlistParams) ([]datastruct.WaitlistEntry, []byte, error)
+	PopWaitlist(ctx context.Context, pId string) (datastruct.WaitlistEntry, error)
+	RequestToJoin(context.Context, repository.UserPartyParams) (datastruct.PartyJoinRequest, error)
+	GetJoinRequests(context.Context, repository.GetJoinRequestsParams) ([]datastruct.PartyJoinRequest, []byte, error)
+	ApproveJoinRequest(context.Context, repository.UserPartyParams) error
+	RejectJoinRequest(context.Context, repository.UserPartyParams) error
+	Ban(context.Context, repository.BanParams) (datastruct.PartyBan, error)
+	Unban(context.Context, repository.UserPartyParams) error
+	IsBanned(context.Context, repository.UserPartyParams) (bool, error)
+	GetBans(context.Context, repository.GetBansParams) ([]datastruct.PartyBan, []byte, error)
+	GetPartyInvites(context.Context, repository.GetPartyInvitesParams) ([]datastruct.PartyInvite, []byte, error)
+	RemoveInvites(ctx context.Context, is []datastruct.PartyInvite) error
+	RemoveParticipants(ctx context.Context, pId string, uIds []string) error
+	RemoveWaitlistEntries(ctx context.Context, pId string, uIds []string) error
+	DeletePartyData(ctx context.Context, pId string) error
+	DeleteParticipantCount(ctx context.Context, pId string) error
+}
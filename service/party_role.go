@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+)
+
+type PartyRoleService interface {
+	AssignRole(ctx context.Context, pId, uId, role string) (datastruct.PartyRole, error)
+	RevokeRole(ctx context.Context, pId, uId string) error
+	GetRole(ctx context.Context, pId, uId string) (datastruct.PartyRole, error)
+	HasRoles(ctx context.Context, pId string) (bool, error)
+	DeletePartyRoles(ctx context.Context, pId string) error
+}
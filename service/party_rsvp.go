@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+)
+
+type PartyRsvpService interface {
+	SetRsvp(ctx context.Context, pId, uId, status string) (datastruct.PartyRsvp, string, error)
+	GetRsvp(ctx context.Context, pId, uId string) (datastruct.PartyRsvp, error)
+	GetRsvpsByStatus(ctx context.Context, pId, status string, page []byte, limit uint64) ([]datastruct.PartyRsvp, []byte, error)
+	IncreaseRsvpCount(ctx context.Context, pId, status string) error
+	DecreaseRsvpCount(ctx context.Context, pId, status string) error
+	GetRsvpCounts(ctx context.Context, pId string) ([]datastruct.PartyRsvpCount, error)
+	GetPartyRsvps(ctx context.Context, pId string, page []byte, limit uint64) ([]datastruct.PartyRsvp, []byte, error)
+	RemoveRsvps(ctx context.Context, pId string, uIds []string) error
+	DeleteRsvpCounts(ctx context.Context, pId string) error
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/clubo-app/relation-service/datastruct"
+)
+
+type PartySettingsService interface {
+	GetPartySettings(ctx context.Context, pId string) (datastruct.PartySettings, error)
+	SetCapacity(ctx context.Context, pId string, capacity int) (datastruct.PartySettings, error)
+	SetPrivate(ctx context.Context, pId string, private bool) (datastruct.PartySettings, error)
+	SetAllowGuestInvites(ctx context.Context, pId string, allow bool) (datastruct.PartySettings, error)
+	SetMaxPlusOnes(ctx context.Context, pId string, max int) (datastruct.PartySettings, error)
+	ReserveSeats(ctx context.Context, pId string, seats int) error
+	ReleaseSeats(ctx context.Context, pId string, seats int) error
+	DeletePartySettings(ctx context.Context, pId string) error
+}
@@ -0,0 +1,126 @@
+package sweeper
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/clubo-app/packages/stream"
+	"github.com/clubo-app/protobuf/events"
+	"github.com/clubo-app/relation-service/repository"
+	"github.com/clubo-app/relation-service/service"
+)
+
+// How many days back the sweeper looks for expired entries, so entries which expired while the service was down still get removed
+const lookbackDays = 7
+
+type sweeper struct {
+	stream   stream.Stream
+	fs       service.FriendRelationService
+	pp       service.PartyParticipantsService
+	fp       service.FavoriteParty
+	interval time.Duration
+}
+
+func New(stream stream.Stream, fs service.FriendRelationService, pp service.PartyParticipantsService, fp service.FavoriteParty, interval time.Duration) sweeper {
+	return sweeper{stream: stream, fs: fs, pp: pp, fp: fp, interval: interval}
+}
+
+func (s sweeper) Start() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	var sweptHour time.Time
+	for range ticker.C {
+		s.SweepFriendRequests()
+		s.SweepPartyInvites()
+		sweptHour = s.SweepHourlyFavoriteCounts(sweptHour)
+	}
+}
+
+func (s sweeper) SweepFriendRequests() {
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := lookbackDays; i >= 0; i-- {
+		day := now.AddDate(0, 0, -i)
+
+		expired, err := s.fs.GetExpiredFriendRequests(ctx, day, now)
+		if err != nil {
+			log.Println("Error getting expired Friend Requests: ", err)
+			continue
+		}
+
+		for _, e := range expired {
+			applied, err := s.fs.ExpireFriendRequest(ctx, e)
+			if err != nil {
+				log.Println("Error expiring Friend Request: ", err)
+				continue
+			}
+			// The request was accepted or declined in the meantime
+			if !applied {
+				continue
+			}
+
+			// The friend_id of a pending request is the user who sent it
+			s.stream.PublishEvent(&events.FriendRequestExpired{
+				UserId:   e.FriendId,
+				FriendId: e.UserId,
+			})
+		}
+	}
+}
+
+func (s sweeper) SweepPartyInvites() {
+	ctx := context.Background()
+	now := time.Now()
+
+	for i := lookbackDays; i >= 0; i-- {
+		day := now.AddDate(0, 0, -i)
+
+		expired, err := s.pp.GetExpiredInvites(ctx, day, now)
+		if err != nil {
+			log.Println("Error getting expired Party Invites: ", err)
+			continue
+		}
+
+		for _, e := range expired {
+			applied, err := s.pp.ExpireInvite(ctx, e)
+			if err != nil {
+				log.Println("Error expiring Party Invite: ", err)
+				continue
+			}
+			// The invite was accepted, declined or renewed in the meantime
+			if !applied {
+				continue
+			}
+
+			s.stream.PublishEvent(&events.PartyInviteExpired{
+				UserId:    e.UserId,
+				PartyId:   e.PartyId,
+				InviterId: e.InviterId,
+			})
+		}
+	}
+}
+
+// Deletes the hourly favorite buckets which fell out of the retention since the last swept hour
+// and returns the new last swept hour
+func (s sweeper) SweepHourlyFavoriteCounts(last time.Time) time.Time {
+	ctx := context.Background()
+	until := time.Now().Add(-repository.HourlyFavoriteRetention).UTC().Truncate(time.Hour)
+
+	if last.IsZero() {
+		last = until.AddDate(0, 0, -lookbackDays)
+	}
+
+	for h := last.Add(time.Hour); !h.After(until); h = h.Add(time.Hour) {
+		err := s.fp.DeleteHourlyFavoriteCounts(ctx, h)
+		if err != nil {
+			log.Println("Error deleting Hourly Favorite Counts: ", err)
+			return h.Add(-time.Hour)
+		}
+	}
+
+	return until
+}
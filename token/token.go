@@ -0,0 +1,56 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Secrets shorter than the sha256 output would make signatures guessable
+const minSecretLength = 32
+
+// Signer issues tokens which can't be forged without knowing the secret
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret string) (Signer, error) {
+	if secret == "" {
+		return Signer{}, errors.New("TOKEN_SECRET has to be set")
+	}
+	if len(secret) < minSecretLength {
+		return Signer{}, errors.New("TOKEN_SECRET has to be at least 32 bytes long")
+	}
+
+	return Signer{secret: []byte(secret)}, nil
+}
+
+// Returns the payload together with its signature
+func (s Signer) Sign(payload string) string {
+	return payload + "." + s.signature(payload)
+}
+
+// Returns the payload of the token if its signature is valid
+func (s Signer) Verify(token string) (string, error) {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", ErrInvalidToken
+	}
+
+	payload, sig := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(sig), []byte(s.signature(payload))) {
+		return "", ErrInvalidToken
+	}
+
+	return payload, nil
+}
+
+func (s Signer) signature(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}